@@ -94,7 +94,16 @@ class Hub;
 class Messager {
  public:
   struct Stats {
-    std::chrono::microseconds duration;  // total load time consuming.
+    std::chrono::microseconds duration{};                         // total load time consuming.
+    std::chrono::microseconds read_duration{};                    // time consuming of reading files.
+    std::chrono::microseconds unmarshal_duration{};               // time consuming of unmarshaling files.
+    std::chrono::microseconds patch_duration{};                   // time consuming of merging patches.
+    std::chrono::microseconds process_after_load_duration{};      // time consuming of ProcessAfterLoad.
+    std::chrono::microseconds process_after_load_all_duration{};  // time consuming of ProcessAfterLoadAll.
+    std::vector<std::filesystem::path> paths;                     // all loaded file paths, including patch files.
+    std::vector<std::filesystem::path> patch_paths;               // applied patch file paths.
+    std::size_t byte_size = 0;                                    // total size of read files.
+    std::size_t entry_count = 0;  // number of entries of first-level maps and lists.
   };
 
  public:
@@ -108,10 +117,24 @@ class Messager {
   virtual const google::protobuf::Message* Message() const { return nullptr; }
   // callback after all messagers loaded.
   virtual bool ProcessAfterLoadAll(const Hub&) { return true; }
+  // Dependencies returns the names of messagers whose ProcessAfterLoadAll
+  // must be invoked before this messager's.
+  virtual std::vector<std::string> Dependencies() const { return {}; }
 
  protected:
   // callback after this messager loaded.
   virtual bool ProcessAfterLoad() { return true; };
   Stats stats_;
+
+ private:
+  friend class Hub;
 };
+
+namespace load {
+// LoadMessagerInDir loads message's content in the given dir, based on format
+// and messager options, and fills the read, unmarshal and patch timings, file
+// paths, byte size and entry count into stats.
+bool LoadMessagerInDir(google::protobuf::Message& msg, const std::filesystem::path& dir, Format fmt,
+                       std::shared_ptr<const MessagerOptions> options, Messager::Stats& stats);
+}  // namespace load
 }  // namespace tableau
\ No newline at end of file
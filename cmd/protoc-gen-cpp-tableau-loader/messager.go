@@ -164,8 +164,11 @@ func genCppMessage(g *protogen.GeneratedFile, message *protogen.Message) {
 	g.P()
 	g.P("bool ", messagerName, "::Load(const std::filesystem::path& dir, Format fmt, std::shared_ptr<const load::MessagerOptions> options /* = nullptr */) {")
 	g.P(helper.Indent(1), "tableau::util::TimeProfiler profiler;")
-	g.P(helper.Indent(1), "bool loaded = LoadMessagerInDir(data_, dir, fmt, options);")
+	g.P(helper.Indent(1), "stats_ = Stats{};")
+	g.P(helper.Indent(1), "bool loaded = LoadMessagerInDir(data_, dir, fmt, options, stats_);")
+	g.P(helper.Indent(1), "tableau::util::TimeProfiler process_profiler;")
 	g.P(helper.Indent(1), "bool ok = loaded ? ProcessAfterLoad() : false;")
+	g.P(helper.Indent(1), "stats_.process_after_load_duration = process_profiler.Elapse();")
 	g.P(helper.Indent(1), "stats_.duration = profiler.Elapse();")
 	g.P(helper.Indent(1), "return ok;")
 	g.P("}")
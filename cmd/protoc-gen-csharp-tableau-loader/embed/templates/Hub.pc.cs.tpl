@@ -89,13 +89,21 @@ namespace Tableau
                     return false;
                 }
             }
+            var names = SortMessagersByDependencies(messagerMap);
+            if (names == null)
+            {
+                return false;
+            }
             var tmpHub = new Hub();
             tmpHub.SetMessagerMap(messagerMap);
-            foreach (var messager in messagerMap)
+            foreach (var name in names)
             {
-                if (!messager.Value.ProcessAfterLoadAll(tmpHub))
+                var start = DateTime.Now;
+                bool ok = messagerMap[name].ProcessAfterLoadAll(tmpHub);
+                messagerMap[name].GetStats().ProcessAfterLoadAllDuration = DateTime.Now - start;
+                if (!ok)
                 {
-                    Console.Error.WriteLine($"hub call ProcessAfterLoadAll failed, messager: {messager.Key}");
+                    Console.Error.WriteLine($"hub call ProcessAfterLoadAll failed, messager: {name}");
                     return false;
                 }
             }
@@ -125,6 +133,60 @@ namespace Tableau
         /// </summary>
         public DateTime? GetLastLoadedTime() => _messagerContainer.Value?.LastLoadedTime;
 
+        /// <summary>
+        /// SortMessagersByDependencies sorts messager names in topological order of
+        /// their dependencies, and independent messagers are sorted by name. It
+        /// returns null if any dependency is missing or cyclic.
+        /// </summary>
+        internal static List<string>? SortMessagersByDependencies(Dictionary<string, Messager> messagerMap)
+        {
+            var states = new Dictionary<string, bool>(); // false: visiting, true: visited
+            var path = new List<string>();
+            var names = new List<string>(messagerMap.Count);
+            bool Visit(string name)
+            {
+                if (states.TryGetValue(name, out var visited))
+                {
+                    if (visited)
+                    {
+                        return true;
+                    }
+                    var cycle = path.GetRange(path.IndexOf(name), path.Count - path.IndexOf(name));
+                    cycle.Add(name);
+                    Console.Error.WriteLine($"messager dependency cycle: {string.Join(" -> ", cycle)}");
+                    return false;
+                }
+                states[name] = false;
+                path.Add(name);
+                foreach (var dep in messagerMap[name].Dependencies())
+                {
+                    if (!messagerMap.ContainsKey(dep))
+                    {
+                        Console.Error.WriteLine($"messager {name} depends on {dep}, which is not registered or filtered out");
+                        return false;
+                    }
+                    if (!Visit(dep))
+                    {
+                        return false;
+                    }
+                }
+                path.RemoveAt(path.Count - 1);
+                states[name] = true;
+                names.Add(name);
+                return true;
+            }
+            var sortedNames = new List<string>(messagerMap.Keys);
+            sortedNames.Sort(string.CompareOrdinal);
+            foreach (var name in sortedNames)
+            {
+                if (!Visit(name))
+                {
+                    return null;
+                }
+            }
+            return names;
+        }
+
         /// <summary>
         /// NewMessagerMap creates a new MessagerMap based on the registered messagers.
         /// </summary>
@@ -75,10 +75,11 @@ func genMessage(gen *protogen.Plugin, g *protogen.GeneratedFile, message *protog
 	g.P(helper.Indent(2), "public override bool Load(string dir, Format fmt, in Load.MessagerOptions? options = null)")
 	g.P(helper.Indent(2), "{")
 	g.P(helper.Indent(3), "var start = DateTime.Now;")
+	g.P(helper.Indent(3), "LoadStats = new Stats();")
 	g.P(helper.Indent(3), "try")
 	g.P(helper.Indent(3), "{")
 	g.P(helper.Indent(4), "_data = (", helper.ParseCsharpClassType(message.Desc), ")(")
-	g.P(helper.Indent(5), "Tableau.Load.LoadMessagerInDir(", helper.ParseCsharpClassType(message.Desc), ".Descriptor, dir, fmt, options)")
+	g.P(helper.Indent(5), "Tableau.Load.LoadMessagerInDir(", helper.ParseCsharpClassType(message.Desc), ".Descriptor, dir, fmt, options, LoadStats)")
 	g.P(helper.Indent(5), "?? throw new InvalidOperationException()")
 	g.P(helper.Indent(4), ");")
 	g.P(helper.Indent(3), "}")
@@ -90,8 +91,11 @@ func genMessage(gen *protogen.Plugin, g *protogen.GeneratedFile, message *protog
 	g.P(helper.Indent(4), "}")
 	g.P(helper.Indent(4), "return false;")
 	g.P(helper.Indent(3), "}")
+	g.P(helper.Indent(3), "var processStart = DateTime.Now;")
+	g.P(helper.Indent(3), "bool ok = ProcessAfterLoad();")
+	g.P(helper.Indent(3), "LoadStats.ProcessAfterLoadDuration = DateTime.Now - processStart;")
 	g.P(helper.Indent(3), "LoadStats.Duration = DateTime.Now - start;")
-	g.P(helper.Indent(3), "return ProcessAfterLoad();")
+	g.P(helper.Indent(3), "return ok;")
 	g.P(helper.Indent(2), "}")
 	g.P()
 	g.P(helper.Indent(2), "/// <summary>")
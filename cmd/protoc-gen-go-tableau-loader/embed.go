@@ -15,6 +15,7 @@ var efs embed.FS
 
 var tpl = template.Must(template.New("").Funcs(template.FuncMap{
 	"toLowerCamel": strcase.ToLowerCamel,
+	"version":      func() string { return version },
 }).ParseFS(efs, "embed/templates/*"))
 
 // generateEmbed generates related registry files.
@@ -0,0 +1,210 @@
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+
+	"github.com/tableauio/tableau/format"
+	"github.com/tableauio/tableau/load"
+	"github.com/tableauio/tableau/store"
+	"google.golang.org/protobuf/proto"
+)
+
+// PluginVersion is the version of protoc-gen-go-tableau-loader which
+// generated this package.
+const PluginVersion = "{{ version }}"
+
+// BundleManifestPath is the path of the manifest in a config bundle.
+const BundleManifestPath = "MANIFEST.json"
+
+// BundleManifest describes the config files packed in a config bundle.
+type BundleManifest struct {
+	PluginVersion string        `json:"pluginVersion"` // version of the plugin which generated the storing hub
+	Format        format.Format `json:"format"`        // format of all config files
+	Messagers     []BundleEntry `json:"messagers"`     // sorted by name
+}
+
+// BundleEntry describes a config file packed in a config bundle.
+type BundleEntry struct {
+	Name   string `json:"name"`   // messager name
+	Path   string `json:"path"`   // config file path in the bundle
+	SHA256 string `json:"sha256"` // hex-encoded SHA-256 checksum of the config file
+}
+
+// StoreBundle packs all loaded messagers into a single zip archive written
+// to w, with a manifest at [BundleManifestPath] holding messager names,
+// checksums, format and plugin version. Messagers without loaded data,
+// e.g. custom messagers, are skipped.
+//
+// Available formats: JSON, Bin, and Text.
+func (h *Hub) StoreBundle(w io.Writer, format format.Format, options ...store.Option) error {
+	opts := store.ParseOptions(options...)
+	manifest := BundleManifest{PluginVersion: PluginVersion, Format: format}
+	zw := zip.NewWriter(w)
+	messagerMap := h.GetMessagerMap()
+	for _, name := range sortedNames(messagerMap) {
+		msg := messagerMap[name].Message()
+		if msg == nil {
+			continue
+		}
+		content, err := marshal(msg, format, opts)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %w", name, err)
+		}
+		path := configFilename(name, format)
+		fw, err := zw.Create(path)
+		if err != nil {
+			return fmt.Errorf("failed to create %s in bundle: %w", path, err)
+		}
+		if _, err := fw.Write(content); err != nil {
+			return fmt.Errorf("failed to write %s in bundle: %w", path, err)
+		}
+		checksum := sha256.Sum256(content)
+		manifest.Messagers = append(manifest.Messagers, BundleEntry{
+			Name:   name,
+			Path:   path,
+			SHA256: hex.EncodeToString(checksum[:]),
+		})
+	}
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal bundle manifest: %w", err)
+	}
+	fw, err := zw.Create(BundleManifestPath)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle manifest: %w", err)
+	}
+	if _, err := fw.Write(content); err != nil {
+		return fmt.Errorf("failed to write bundle manifest: %w", err)
+	}
+	return zw.Close()
+}
+
+// LoadBundle fills messages from a config bundle read from r, which is
+// packed by [Hub.StoreBundle]. The manifest is verified before building a
+// container: the plugin version must match [PluginVersion], and all listed
+// config files must exist with matching checksums. Only the messagers listed
+// in the manifest are loaded, each from its verified config file, besides
+// messagers without loaded data, e.g. custom messagers. The bundle is
+// rejected if a messager to be loaded depends on an unlisted one. Patch dirs
+// are resolved inside the bundle, as [Hub.LoadFS] does.
+func (h *Hub) LoadBundle(r io.Reader, options ...load.Option) error {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("failed to read bundle: %w", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return fmt.Errorf("failed to open bundle: %w", err)
+	}
+	manifest, err := ReadBundleManifest(zr)
+	if err != nil {
+		return err
+	}
+	if err := manifest.Verify(zr); err != nil {
+		return err
+	}
+	messagerOptions := maps.Clone(load.ParseOptions(options...).MessagerOptions)
+	if messagerOptions == nil {
+		messagerOptions = map[string]*load.MessagerOptions{}
+	}
+	listed := make(map[string]bool, len(manifest.Messagers))
+	for _, entry := range manifest.Messagers {
+		// load from the verified config file only
+		mopts := &load.MessagerOptions{}
+		if messagerOptions[entry.Name] != nil {
+			*mopts = *messagerOptions[entry.Name]
+		}
+		mopts.Path = entry.Path
+		messagerOptions[entry.Name] = mopts
+		listed[entry.Name] = true
+	}
+	messagerMap := h.NewMessagerMap()
+	unlisted := map[string]bool{}
+	for name, msger := range messagerMap {
+		// messagers loaded from config files
+		if _, ok := msger.(messageLoader); ok && !listed[name] {
+			unlisted[name] = true
+			delete(messagerMap, name)
+		}
+	}
+	for _, name := range sortedNames(messagerMap) {
+		for _, dep := range messagerDependencies(messagerMap[name]) {
+			if unlisted[dep] {
+				return fmt.Errorf("bundle manifest leaves out %s, which %s depends on", dep, name)
+			}
+		}
+	}
+	options = append(options, load.WithMessagerOptions(messagerOptions))
+	pending, err := h.prepare(context.Background(), zr, messagerMap, messagerMap, ".", manifest.Format, options...)
+	if err != nil {
+		return err
+	}
+	return pending.Commit()
+}
+
+// ReadBundleManifest reads the manifest of a config bundle opened as fsys.
+func ReadBundleManifest(fsys fs.FS) (*BundleManifest, error) {
+	content, err := fs.ReadFile(fsys, BundleManifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundle manifest: %w", err)
+	}
+	manifest := &BundleManifest{}
+	if err := json.Unmarshal(content, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse bundle manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+// Verify checks that the bundle is packed by the same plugin version, the
+// format is an output format, and all config files listed in the manifest
+// exist in fsys with matching checksums.
+func (m *BundleManifest) Verify(fsys fs.FS) error {
+	if m.PluginVersion != PluginVersion {
+		return fmt.Errorf("bundle plugin version mismatch: %s, expected %s", m.PluginVersion, PluginVersion)
+	}
+	switch m.Format {
+	case format.JSON, format.Bin, format.Text:
+	default:
+		return fmt.Errorf("invalid bundle format: %q", m.Format)
+	}
+	for _, entry := range m.Messagers {
+		content, err := fs.ReadFile(fsys, entry.Path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s in bundle: %w", entry.Name, err)
+		}
+		checksum := sha256.Sum256(content)
+		if hex.EncodeToString(checksum[:]) != entry.SHA256 {
+			return fmt.Errorf("checksum mismatch of %s in bundle: %s", entry.Name, entry.Path)
+		}
+	}
+	return nil
+}
+
+// marshal marshals msg in the specified format, as [store.Store] does.
+func marshal(msg proto.Message, fmt format.Format, opts *store.Options) ([]byte, error) {
+	switch fmt {
+	case format.JSON:
+		return store.MarshalToJSON(msg, &store.MarshalOptions{
+			LocationName:    opts.LocationName,
+			Pretty:          opts.Pretty,
+			EmitUnpopulated: opts.EmitUnpopulated,
+			EmitTimezones:   opts.EmitTimezones,
+			UseProtoNames:   opts.UseProtoNames,
+			UseEnumNumbers:  opts.UseEnumNumbers,
+		})
+	case format.Text:
+		return store.MarshalToText(msg, opts.Pretty)
+	case format.Bin:
+		return store.MarshalToBin(msg)
+	default:
+		return nil, errors.New("unknown output format: " + string(fmt))
+	}
+}
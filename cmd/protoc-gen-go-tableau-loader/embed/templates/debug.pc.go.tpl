@@ -0,0 +1,270 @@
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// DebugHandler returns a read-only [http.Handler] for inspecting the
+// configs which the hub actually has. Paths are relative to where the
+// handler is mounted, e.g. by [http.StripPrefix]:
+//   - GET /: list all messagers with stats and indexes, and the
+//     generation of the current container.
+//   - GET /{messager}: dump the messager as JSON.
+//   - GET /{messager}/get?key=k1&key=k2: look up the value by map keys
+//     level by level, as the generated GetN does.
+//   - GET /{messager}/find/{index}?key=k1&key=k2: look up the values by
+//     index keys, as the generated Find{index} does.
+func (h *Hub) DebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if parts[0] == "" {
+			writeDebugJSON(w, h.debugList())
+			return
+		}
+		msger := h.GetMessager(parts[0])
+		if msger == nil {
+			http.Error(w, fmt.Sprintf("messager %s not found", parts[0]), http.StatusNotFound)
+			return
+		}
+		if msger.Message() == nil {
+			http.Error(w, fmt.Sprintf("messager %s has no data", parts[0]), http.StatusNotFound)
+			return
+		}
+		keys := r.URL.Query()["key"]
+		var result any
+		var err error
+		switch {
+		case len(parts) == 1:
+			result = json.RawMessage(marshalDebugMessage(msger.Message()))
+		case len(parts) == 2 && parts[1] == "get":
+			result, err = debugGet(msger.Message().ProtoReflect(), keys)
+		case len(parts) == 3 && parts[1] == "find":
+			result, err = debugFind(msger, parts[2], keys)
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		if err != nil {
+			status := http.StatusBadRequest
+			if errors.Is(err, ErrNotFound) {
+				status = http.StatusNotFound
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		writeDebugJSON(w, result)
+	})
+}
+
+type debugMessager struct {
+	Name    string   `json:"name"`
+	Stats   *Stats   `json:"stats"`
+	Indexes []string `json:"indexes,omitempty"`
+}
+
+type debugContainer struct {
+	Generation     uint64          `json:"generation"`
+	LastLoadedTime time.Time       `json:"lastLoadedTime"`
+	Messagers      []debugMessager `json:"messagers"`
+}
+
+func (h *Hub) debugList() *debugContainer {
+	mc := h.mc.Load()
+	list := &debugContainer{Generation: mc.GetGeneration(), LastLoadedTime: mc.GetLastLoadedTime()}
+	for _, name := range sortedNames(mc.GetMessagerMap()) {
+		msger := mc.GetMessager(name)
+		list.Messagers = append(list.Messagers, debugMessager{
+			Name:    name,
+			Stats:   msger.GetStats(),
+			Indexes: debugIndexes(msger),
+		})
+	}
+	return list
+}
+
+func writeDebugJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+func marshalDebugMessage(msg proto.Message) []byte {
+	content, err := protojson.Marshal(msg)
+	if err != nil {
+		// should not happen, as messages are loaded successfully
+		content, _ = json.Marshal(err.Error())
+	}
+	return content
+}
+
+// debugGet looks up the value by keys of the first map field of each level.
+func debugGet(msg protoreflect.Message, keys []string) (any, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no key specified")
+	}
+	var value protoreflect.Value
+	for i, key := range keys {
+		if msg == nil {
+			return nil, fmt.Errorf("level %d has no map", i+1)
+		}
+		var fd protoreflect.FieldDescriptor
+		fields := msg.Descriptor().Fields()
+		for j := 0; j < fields.Len(); j++ {
+			if fields.Get(j).IsMap() {
+				fd = fields.Get(j)
+				break
+			}
+		}
+		if fd == nil {
+			return nil, fmt.Errorf("level %d has no map", i+1)
+		}
+		mapKey, err := parseDebugMapKey(fd.MapKey(), key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %q of level %d: %w", key, i+1, err)
+		}
+		value = msg.Get(fd).Map().Get(mapKey)
+		if !value.IsValid() {
+			return nil, fmt.Errorf("key %s of level %d: %w", key, i+1, ErrNotFound)
+		}
+		msg = nil
+		if fd.MapValue().Kind() == protoreflect.MessageKind {
+			msg = value.Message()
+		}
+	}
+	if msg != nil {
+		return json.RawMessage(marshalDebugMessage(msg.Interface())), nil
+	}
+	return value.Interface(), nil
+}
+
+func parseDebugMapKey(fd protoreflect.FieldDescriptor, key string) (protoreflect.MapKey, error) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(key).MapKey(), nil
+	case protoreflect.BoolKind:
+		v, err := strconv.ParseBool(key)
+		return protoreflect.ValueOfBool(v).MapKey(), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		v, err := strconv.ParseInt(key, 10, 32)
+		return protoreflect.ValueOfInt32(int32(v)).MapKey(), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		v, err := strconv.ParseInt(key, 10, 64)
+		return protoreflect.ValueOfInt64(v).MapKey(), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		v, err := strconv.ParseUint(key, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(v)).MapKey(), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		v, err := strconv.ParseUint(key, 10, 64)
+		return protoreflect.ValueOfUint64(v).MapKey(), err
+	default:
+		return protoreflect.MapKey{}, fmt.Errorf("unsupported map key kind: %v", fd.Kind())
+	}
+}
+
+var protoMessageType = reflect.TypeFor[proto.Message]()
+
+// debugIndexes returns the names of indexes of the messager, which are
+// found by the generated Find{index} methods returning a list of messages.
+func debugIndexes(msger Messager) []string {
+	var indexes []string
+	t := reflect.TypeOf(msger)
+	for i := 0; i < t.NumMethod(); i++ {
+		method := t.Method(i)
+		name, ok := strings.CutPrefix(method.Name, "Find")
+		if ok && !strings.HasPrefix(name, "First") && isDebugFinder(method.Type) {
+			indexes = append(indexes, name)
+		}
+	}
+	return indexes
+}
+
+// isDebugFinder reports whether the method type, with receiver as the first
+// param, returns only a list of messages.
+func isDebugFinder(t reflect.Type) bool {
+	return t.NumOut() == 1 && t.Out(0).Kind() == reflect.Slice && t.Out(0).Elem().Implements(protoMessageType)
+}
+
+// debugFind looks up the values by the generated Find{index} method, with
+// keys converted to its param types.
+func debugFind(msger Messager, index string, keys []string) (any, error) {
+	method, ok := reflect.TypeOf(msger).MethodByName("Find" + index)
+	if !ok || strings.HasPrefix(index, "First") || !isDebugFinder(method.Type) {
+		return nil, fmt.Errorf("index %s: %w", index, ErrNotFound)
+	}
+	if method.Type.NumIn()-1 != len(keys) {
+		return nil, fmt.Errorf("index %s requires %d keys, but got %d", index, method.Type.NumIn()-1, len(keys))
+	}
+	args := []reflect.Value{reflect.ValueOf(msger)}
+	for i, key := range keys {
+		arg, err := parseDebugArg(method.Type.In(i+1), key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %q of index %s: %w", key, index, err)
+		}
+		args = append(args, arg)
+	}
+	values := method.Func.Call(args)[0]
+	results := make([]json.RawMessage, values.Len())
+	for i := range results {
+		results[i] = marshalDebugMessage(values.Index(i).Interface().(proto.Message))
+	}
+	return results, nil
+}
+
+var protoEnumType = reflect.TypeFor[protoreflect.Enum]()
+
+func parseDebugArg(t reflect.Type, key string) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+	if t.Implements(protoEnumType) {
+		ed := v.Interface().(protoreflect.Enum).Descriptor()
+		if evd := ed.Values().ByName(protoreflect.Name(key)); evd != nil {
+			v.SetInt(int64(evd.Number()))
+			return v, nil
+		}
+	}
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(key)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(key)
+		if err != nil {
+			return v, err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(key, 10, t.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(key, 10, t.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(key, t.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetFloat(f)
+	default:
+		return v, fmt.Errorf("unsupported key type: %v", t)
+	}
+	return v, nil
+}
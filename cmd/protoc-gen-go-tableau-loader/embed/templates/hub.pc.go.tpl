@@ -1,11 +1,19 @@
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
+	"log/slog"
+	"maps"
 	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
-	"github.com/tableauio/loader/pkg/udiff"
+	"github.com/tableauio/loader/pkg/fswatch"
 	"github.com/tableauio/tableau/format"
 	"github.com/tableauio/tableau/load"
 	"github.com/tableauio/tableau/store"
@@ -24,6 +32,99 @@ type Options struct {
 	//
 	// Default: nil.
 	MutableCheck *MutableCheck
+
+	// LoadConcurrency specifies the max number of messagers loaded
+	// concurrently. A value less than or equal to 1 means loading
+	// messagers one by one.
+	//
+	// Default: 1.
+	LoadConcurrency int
+
+	// HotReload specifies how [Hub.Watch] detects changed config files
+	// and reloads them.
+	//
+	// Default: nil.
+	HotReload *HotReload
+
+	// OnReload is called after a new messager container is set, with the
+	// old and new containers, and the sorted names of messagers whose
+	// content actually differs (by [proto.Equal]), including added and
+	// removed ones.
+	//
+	// Default: nil.
+	OnReload func(old, new *MessagerContainer, changed []string)
+
+	// CollectErrors collects all failures of messagers into one joined
+	// error, instead of returning on the first failure. Each failure is a
+	// [*LoadError], which can be inspected by [errors.As].
+	//
+	// Default: false.
+	CollectErrors bool
+
+	// SkipValidators skips the validators registered by [RegisterValidator].
+	//
+	// Default: false.
+	SkipValidators bool
+
+	// SkipReferChecks skips checking that fields with refer prop reference
+	// existing keys of the referred messagers.
+	//
+	// Default: false.
+	SkipReferChecks bool
+
+	// KeepGenerations specifies the max number of previous messager
+	// containers kept for [Hub.Rollback].
+	//
+	// Default: 0.
+	KeepGenerations int
+
+	// Optional reports whether the named messager is optional, in addition
+	// to messagers labeled "optional" in worksheet options. Optional
+	// messagers tolerate missing config files: such messagers are left out
+	// of the loaded container, and the missing files are reported by
+	// [MessagerContainer.GetMissingFiles].
+	//
+	// Default: nil.
+	Optional FilterFunc
+
+	// Profiles maps each profile name to a [Profile], which can be loaded by
+	// [Hub.LoadProfile].
+	//
+	// Default: nil.
+	Profiles map[string]*Profile
+
+	// LoadObserver observes the lifecycle of each load, e.g. for tracing.
+	//
+	// Default: nil.
+	LoadObserver LoadObserver
+
+	// Logger receives the structured events of the hub, e.g. load start and
+	// finish, per-messager timings, patch application, mutation detection
+	// and reload failures, with attribute keys LogKey*.
+	//
+	// Default: nil, which discards all events.
+	Logger *slog.Logger
+
+	// LoadTimeout specifies the max duration of loading each messager,
+	// after which the loading is aborted and fails with
+	// [context.DeadlineExceeded]. A value less than or equal to 0 means no
+	// timeout.
+	//
+	// Default: 0.
+	LoadTimeout time.Duration
+
+	// MessagerLoadTimeouts maps each messager name to its LoadTimeout,
+	// which overrides the global-level one.
+	//
+	// Default: nil.
+	MessagerLoadTimeouts map[string]time.Duration
+
+	// Messagers maps each messager name to its generator registered to
+	// this hub only, which overrides the one registered by [Register] of
+	// the same name.
+	//
+	// Default: nil.
+	Messagers map[string]MessagerGenerator
 }
 
 // FilterFunc filter in messagers if returned value is true.
@@ -35,9 +136,31 @@ type MutableCheck struct {
 	// Interval is the gap duration between two checks.
 	// Default: 60s.
 	Interval time.Duration
+	// Pacing is the gap duration between checking two messagers in a check,
+	// so that a check does not hog the CPU. A negative value means no gap.
+	// Default: 1s.
+	Pacing time.Duration
 	// OnMutate is called when encouters mutations, with messager's name,
 	// original message and current message.
 	OnMutate func(name string, original, current proto.Message)
+	// OnMutationReport is called when encouters mutations, with the report
+	// listing the changed field paths. It takes precedence over OnMutate.
+	// If neither is specified, the report is logged by [WithLogger], or
+	// printed to stderr if no logger specified.
+	OnMutationReport func(report *MutationReport)
+}
+
+type HotReload struct {
+	// Backend detects changed config files.
+	// Default: fswatch.Poll(time.Second).
+	Backend fswatch.Backend
+	// Debounce is the quiet duration to wait after the last detected change
+	// before reloading, so that a burst of writes triggers only one reload.
+	// Default: 500ms.
+	Debounce time.Duration
+	// OnError is called when a reload fails, and the current messager
+	// container is kept.
+	OnError func(err error)
 }
 
 // Option is the functional option type.
@@ -73,26 +196,173 @@ func WithMutableCheck(check *MutableCheck) Option {
 	}
 }
 
+// WithLoadConcurrency loads messagers on a bounded worker pool with at
+// most n messagers loaded concurrently.
+func WithLoadConcurrency(n int) Option {
+	return func(opts *Options) {
+		opts.LoadConcurrency = n
+	}
+}
+
+// WithHotReload specifies how [Hub.Watch] detects changed config files
+// and reloads them.
+func WithHotReload(hotReload *HotReload) Option {
+	return func(opts *Options) {
+		opts.HotReload = hotReload
+	}
+}
+
+// WithOnReload specifies the callback called after a new messager
+// container is set.
+func WithOnReload(onReload func(old, new *MessagerContainer, changed []string)) Option {
+	return func(opts *Options) {
+		opts.OnReload = onReload
+	}
+}
+
+// WithCollectErrors collects all failures of messagers into one joined
+// error, instead of returning on the first failure.
+func WithCollectErrors() Option {
+	return func(opts *Options) {
+		opts.CollectErrors = true
+	}
+}
+
+// SkipValidators skips the validators registered by [RegisterValidator],
+// e.g. for tools inspecting broken configs.
+func SkipValidators() Option {
+	return func(opts *Options) {
+		opts.SkipValidators = true
+	}
+}
+
+// SkipReferChecks skips checking that fields with refer prop reference
+// existing keys of the referred messagers.
+func SkipReferChecks() Option {
+	return func(opts *Options) {
+		opts.SkipReferChecks = true
+	}
+}
+
+// WithOptional specifies the optional messagers, which tolerate missing
+// config files.
+func WithOptional(optional FilterFunc) Option {
+	return func(opts *Options) {
+		opts.Optional = optional
+	}
+}
+
+// WithLoadObserver specifies the observer of the lifecycle of each load.
+func WithLoadObserver(observer LoadObserver) Option {
+	return func(opts *Options) {
+		opts.LoadObserver = observer
+	}
+}
+
+// WithLogger specifies the logger receiving the structured events of the
+// hub.
+func WithLogger(logger *slog.Logger) Option {
+	return func(opts *Options) {
+		opts.Logger = logger
+	}
+}
+
+// WithProfile registers a named profile, which can be loaded by
+// [Hub.LoadProfile].
+func WithProfile(name string, profile *Profile) Option {
+	return func(opts *Options) {
+		if opts.Profiles == nil {
+			opts.Profiles = map[string]*Profile{}
+		}
+		opts.Profiles[name] = profile
+	}
+}
+
+// WithLoadTimeout specifies the max duration of loading each messager.
+func WithLoadTimeout(timeout time.Duration) Option {
+	return func(opts *Options) {
+		opts.LoadTimeout = timeout
+	}
+}
+
+// WithMessagerLoadTimeout specifies the max duration of loading the named
+// messager, which overrides the one specified by [WithLoadTimeout].
+func WithMessagerLoadTimeout(name string, timeout time.Duration) Option {
+	return func(opts *Options) {
+		if opts.MessagerLoadTimeouts == nil {
+			opts.MessagerLoadTimeouts = map[string]time.Duration{}
+		}
+		opts.MessagerLoadTimeouts[name] = timeout
+	}
+}
+
+// WithMessager registers a messager to this hub only, besides the ones
+// registered by [Register], e.g. for tests.
+func WithMessager(gen MessagerGenerator) Option {
+	return func(opts *Options) {
+		if opts.Messagers == nil {
+			opts.Messagers = map[string]MessagerGenerator{}
+		}
+		opts.Messagers[gen().Name()] = gen
+	}
+}
+
+// WithKeepGenerations keeps at most n previous messager containers, so
+// that the hub can be rolled back to them by [Hub.Rollback].
+func WithKeepGenerations(n int) Option {
+	return func(opts *Options) {
+		opts.KeepGenerations = n
+	}
+}
+
 // Hub is the messager manager.
 type Hub struct {
-	mc   atomic.Pointer[MessagerContainer]
-	opts *Options
+	mc      atomic.Pointer[MessagerContainer]
+	opts    *Options
+	metrics hubMetrics
+
+	done      chan struct{}  // closed by Close to stop background work
+	closeOnce sync.Once      // closes done only once
+	wg        sync.WaitGroup // waits for background work to stop
+
+	mu         sync.Mutex           // guards fields below
+	generation uint64               // generation of the latest set container
+	history    []*MessagerContainer // previous containers, the latest last
 }
 
 func NewHub(options ...Option) *Hub {
-	hub := &Hub{}
+	hub := &Hub{done: make(chan struct{})}
 	hub.mc.Store(&MessagerContainer{})
 	hub.opts = ParseOptions(options...)
 	if hub.opts.MutableCheck != nil {
-		go hub.mutableCheck()
+		hub.wg.Add(1)
+		go func() {
+			defer hub.wg.Done()
+			hub.mutableCheck()
+		}()
 	}
 	return hub
 }
 
+// Close stops the background work of the hub, e.g. the mutable check, and
+// waits for it to exit. The loaded configs are still accessible after
+// closed. It is safe to call Close multiple times, and it is a no-op on a
+// hub not created by [NewHub].
+func (h *Hub) Close() error {
+	if h.done == nil {
+		return nil
+	}
+	h.closeOnce.Do(func() {
+		close(h.done)
+	})
+	h.wg.Wait()
+	return nil
+}
+
 // NewMessagerMap creates a new MessagerMap.
 func (h *Hub) NewMessagerMap() MessagerMap {
 	messagerMap := MessagerMap{}
-	for name, gen := range getRegistrar().Generators {
+	for name, gen := range h.generators() {
 		if h.opts.Filter == nil || h.opts.Filter(name) {
 			messager := gen()
 			if h.opts.MutableCheck != nil {
@@ -104,32 +374,636 @@ func (h *Hub) NewMessagerMap() MessagerMap {
 	return messagerMap
 }
 
+// generators returns the messager generators of this hub, registered by
+// [Register] and [WithMessager].
+func (h *Hub) generators() map[string]MessagerGenerator {
+	if len(h.opts.Messagers) == 0 {
+		return getRegistrar().Generators
+	}
+	generators := maps.Clone(getRegistrar().Generators)
+	maps.Copy(generators, h.opts.Messagers)
+	return generators
+}
+
 func (h *Hub) SetMessagerMap(messagerMap MessagerMap) {
-	h.mc.Store(newMessagerContainer(messagerMap))
+	h.setContainer(newMessagerContainer(messagerMap))
+}
+
+// setContainer takes mc into effect as a new generation.
+func (h *Hub) setContainer(mc *MessagerContainer) {
+	h.mu.Lock()
+	h.generation++
+	mc.generation = h.generation
+	old := h.mc.Swap(mc)
+	if old.generation != 0 && h.opts.KeepGenerations > 0 {
+		h.history = append(h.history, old)
+		if len(h.history) > h.opts.KeepGenerations {
+			h.history = slices.Delete(h.history, 0, len(h.history)-h.opts.KeepGenerations)
+		}
+	}
+	h.mu.Unlock()
+	h.logger().Info("container set", LogKeyGeneration, mc.generation)
+	h.observer().OnCommit(mc.generation)
+	if h.opts.OnReload != nil {
+		h.opts.OnReload(old, mc, changedMessagers(old, mc))
+	}
+}
+
+// ErrNoGeneration is returned by [Hub.Rollback] if there is no previous
+// messager container kept.
+var ErrNoGeneration = errors.New("no previous generation to roll back to")
+
+// Rollback restores the latest previous messager container kept by
+// [WithKeepGenerations], and drops the current one. It can be called
+// repeatedly to roll back further generations.
+func (h *Hub) Rollback() error {
+	h.mu.Lock()
+	if len(h.history) == 0 {
+		h.mu.Unlock()
+		return ErrNoGeneration
+	}
+	mc := h.history[len(h.history)-1]
+	h.history = h.history[:len(h.history)-1]
+	old := h.mc.Swap(mc)
+	h.mu.Unlock()
+	h.metrics.rollbacks.Add(1)
+	h.logger().Info("container rolled back", LogKeyGeneration, mc.generation)
+	h.observer().OnCommit(mc.generation)
+	if h.opts.OnReload != nil {
+		h.opts.OnReload(old, mc, changedMessagers(old, mc))
+	}
+	return nil
+}
+
+// Generation returns the generation of the current messager container.
+// Generations start from 1 and increase monotonically on each set
+// container, and 0 means no container has been set yet.
+func (h *Hub) Generation() uint64 {
+	return h.mc.Load().GetGeneration()
+}
+
+// changedMessagers returns the sorted names of messagers whose content
+// differs between the old and new containers.
+func changedMessagers(old, new *MessagerContainer) []string {
+	var changed []string
+	for name, msger := range new.GetMessagerMap() {
+		oldMsger, ok := old.GetMessagerMap()[name]
+		if !ok || (oldMsger != msger && !proto.Equal(oldMsger.Message(), msger.Message())) {
+			changed = append(changed, name)
+		}
+	}
+	for name := range old.GetMessagerMap() {
+		if _, ok := new.GetMessagerMap()[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
 }
 
 // Load fills messages from files in the specified directory and format.
 func (h *Hub) Load(dir string, format format.Format, options ...load.Option) error {
+	return h.LoadContext(context.Background(), dir, format, options...)
+}
+
+// LoadContext is like [Hub.Load], but aborts when ctx is done, e.g. on
+// graceful shutdown. The current container is kept untouched if the
+// loading is aborted.
+func (h *Hub) LoadContext(ctx context.Context, dir string, format format.Format, options ...load.Option) error {
+	pending, err := h.PrepareContext(ctx, dir, format, options...)
+	if err != nil {
+		return err
+	}
+	if err := context.Cause(ctx); err != nil {
+		pending.Discard()
+		return err
+	}
+	return pending.Commit()
+}
+
+// Prepare fills messages from files in the specified directory and format
+// into a staged container, without taking it into effect. Call
+// [PendingContainer.Commit] to take it into effect, e.g. at the exact frame
+// of your app's main loop, or [PendingContainer.Discard] to drop it.
+func (h *Hub) Prepare(dir string, format format.Format, options ...load.Option) (*PendingContainer, error) {
+	return h.PrepareContext(context.Background(), dir, format, options...)
+}
+
+// PrepareContext is like [Hub.Prepare], but aborts when ctx is done.
+func (h *Hub) PrepareContext(ctx context.Context, dir string, format format.Format, options ...load.Option) (*PendingContainer, error) {
+	messagerMap := h.NewMessagerMap()
+	return h.prepare(ctx, nil, messagerMap, messagerMap, dir, format, options...)
+}
+
+// LoadFS fills messages from files in the specified directory of fsys and
+// format. The per-messager Path, PatchPaths and PatchDirs options are all
+// resolved inside fsys, and ReadFunc is overridden to read from fsys.
+// Custom messagers load nothing unless they override LoadFS of
+// [UnimplementedMessager].
+//
+// NOTE: only output formats (JSON, Bin, Text) are supported.
+func (h *Hub) LoadFS(fsys fs.FS, dir string, format format.Format, options ...load.Option) error {
+	pending, err := h.PrepareFS(fsys, dir, format, options...)
+	if err != nil {
+		return err
+	}
+	return pending.Commit()
+}
+
+// PrepareFS is like [Hub.Prepare], but fills messages from files in the
+// specified directory of fsys, as [Hub.LoadFS] does.
+func (h *Hub) PrepareFS(fsys fs.FS, dir string, format format.Format, options ...load.Option) (*PendingContainer, error) {
 	messagerMap := h.NewMessagerMap()
+	return h.prepare(context.Background(), fsys, messagerMap, messagerMap, dir, format, options...)
+}
+
+// Reload fills only the named messagers from files in the specified
+// directory and format, and reuses the other loaded messager instances of
+// the current container in the new one. Messagers without loaded data, e.g.
+// custom messagers, and messagers depending on reloaded ones are always
+// created anew. ProcessAfterLoadAll is run only on the new instances, so
+// that the reused instances, which are still in effect, are untouched.
+// The profile of the current container, if any, is applied as
+// [Hub.LoadProfile] does, and kept in the new container.
+func (h *Hub) Reload(dir string, format format.Format, names []string, options ...load.Option) error {
+	profile := h.GetProfile()
+	options, err := h.profileOptions(profile, options)
+	if err != nil {
+		return err
+	}
+	reloadNames := make(map[string]bool, len(names))
+	for _, name := range names {
+		reloadNames[name] = true
+	}
+	messagerMap, loadMap := h.reuseMessagers(func(name string) bool { return reloadNames[name] })
+	for _, name := range names {
+		if _, ok := messagerMap[name]; !ok {
+			return fmt.Errorf("failed to reload %s: messager not registered or filtered out", name)
+		}
+	}
+	pending, err := h.prepare(context.Background(), nil, messagerMap, loadMap, dir, format, options...)
+	if err != nil {
+		return err
+	}
+	pending.mc.profile = profile
+	return pending.Commit()
+}
+
+// reuseMessagers creates a new messager map, in which the loaded messager
+// instances of the current container are reused unless reload reports
+// true or they depend on messagers to be loaded, and returns it with the
+// map of messagers to be loaded.
+func (h *Hub) reuseMessagers(reload func(name string) bool) (messagerMap, loadMap MessagerMap) {
+	current := h.GetMessagerMap()
+	newMap := h.NewMessagerMap()
+	messagerMap, loadMap = MessagerMap{}, MessagerMap{}
+	for name, msger := range newMap {
+		if old, ok := current[name]; ok && !reload(name) && old.Message() != nil {
+			messagerMap[name] = old
+			continue
+		}
+		messagerMap[name] = msger
+		loadMap[name] = msger
+	}
+	isLoaded := func(name string) bool {
+		_, ok := loadMap[name]
+		return ok
+	}
+	for changed := true; changed; {
+		changed = false
+		for name, msger := range messagerMap {
+			if !isLoaded(name) && slices.ContainsFunc(messagerDependencies(msger), isLoaded) {
+				messagerMap[name] = newMap[name]
+				loadMap[name] = newMap[name]
+				changed = true
+			}
+		}
+	}
+	return messagerMap, loadMap
+}
+
+// prepare loads the messagers in loadMap from fsys, or the OS file system
+// if fsys is nil, and then post-processes all messagers in messagerMap into
+// a staged container. It aborts when ctx is done.
+func (h *Hub) prepare(ctx context.Context, fsys fs.FS, messagerMap, loadMap MessagerMap, dir string, format format.Format, options ...load.Option) (_ *PendingContainer, err error) {
+	ctx, end := h.startLoad(ctx, loadMap, LogKeyDir, dir, LogKeyFormat, format)
+	defer func() { end(err) }()
 	opts := load.ParseOptions(options...)
-	for name, msger := range messagerMap {
-		mopts := opts.ParseMessagerOptionsByName(name)
-		if err := msger.Load(dir, format, mopts); err != nil {
-			return fmt.Errorf("failed to load %s: %w", name, err)
+	missing, err := h.loadMessagers(ctx, fsys, loadMap, dir, format, opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range missing {
+		// leave it out, so that GetXxx() returns nil
+		delete(messagerMap, file.Messager)
+	}
+	if err := context.Cause(ctx); err != nil {
+		return nil, err
+	}
+	mc := newMessagerContainer(messagerMap)
+	mc.missingFiles = missing
+	if err := h.observePostProcess(ctx, mc, loadMap); err != nil {
+		return nil, err
+	}
+	return &PendingContainer{hub: h, mc: mc}, nil
+}
+
+// startLoad logs, observes and counts a load of the messagers in loadMap,
+// with attrs describing where they are loaded from. It returns the context
+// derived by the observer, and a func to be called with the result when the
+// load is done.
+func (h *Hub) startLoad(ctx context.Context, loadMap MessagerMap, attrs ...any) (context.Context, func(err error)) {
+	logger := h.logger().With(attrs...)
+	logger.Info("load started", LogKeyCount, len(loadMap))
+	observer := h.observer()
+	ctx = observer.OnLoadStart(ctx, sortedNames(loadMap))
+	start := time.Now()
+	return ctx, func(err error) {
+		observer.OnLoadEnd(ctx, time.Since(start), err)
+		if err != nil {
+			h.metrics.loadFailures.Add(1)
+			logger.Error("load failed", LogKeyDuration, time.Since(start), LogKeyError, err)
+			return
 		}
+		logger.Info("load finished", LogKeyDuration, time.Since(start), LogKeyCount, len(loadMap))
 	}
+}
+
+// observePostProcess runs postProcess, and reports it to the observer with
+// the context returned by OnLoadStart.
+func (h *Hub) observePostProcess(ctx context.Context, mc *MessagerContainer, loadMap MessagerMap) error {
+	start := time.Now()
+	err := h.postProcess(mc, loadMap)
+	h.observer().OnPostProcess(ctx, time.Since(start), err)
+	return err
+}
+
+// postProcess runs ProcessAfterLoadAll of the messagers in loadMap in
+// dependency order, and then checks references and runs validators on all
+// messagers in mc. The other messagers in mc are reused instances which may
+// be still in effect, so they are not touched.
+func (h *Hub) postProcess(mc *MessagerContainer, loadMap MessagerMap) error {
 	// create a temporary hub with messager container for post process
 	tmpHub := &Hub{}
-	tmpHub.SetMessagerMap(messagerMap)
-	for name, msger := range messagerMap {
-		if err := msger.ProcessAfterLoadAll(tmpHub); err != nil {
-			return fmt.Errorf("failed to process messager %s after load all: %w", name, err)
+	tmpHub.mc.Store(mc)
+	messagerMap := mc.GetMessagerMap()
+	names, err := sortMessagersByDependencies(messagerMap)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	failed := map[string]bool{}
+	for _, name := range names {
+		msger := messagerMap[name]
+		if _, ok := loadMap[name]; !ok {
+			continue
 		}
+		if slices.ContainsFunc(messagerDependencies(msger), func(dep string) bool { return failed[dep] }) {
+			// skip it, as the failures of its dependencies are reported
+			failed[name] = true
+			continue
+		}
+		start := time.Now()
+		err := msger.ProcessAfterLoadAll(tmpHub)
+		msger.GetStats().ProcessAfterLoadAllDuration = time.Since(start)
+		if err != nil {
+			err = asLoadError(name, PhaseProcessAfterLoadAll, err)
+			if !h.opts.CollectErrors {
+				return err
+			}
+			errs = append(errs, err)
+			failed[name] = true
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	if err := h.checkRefer(mc); err != nil {
+		return err
+	}
+	return h.validate(mc)
+}
+
+// checkRefer checks the references of all messagers in the loaded
+// container, and returns all dangling references joined.
+func (h *Hub) checkRefer(mc *MessagerContainer) error {
+	if h.opts.SkipReferChecks {
+		return nil
+	}
+	messagerMap := mc.GetMessagerMap()
+	var errs []error
+	for _, name := range sortedNames(messagerMap) {
+		checker, ok := messagerMap[name].(referChecker)
+		if !ok {
+			continue
+		}
+		if err := checker.checkRefer(mc); err != nil {
+			errs = append(errs, &LoadError{Messager: name, Phase: PhaseCheckRefer, Err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validate runs the registered validators on the loaded container, and
+// returns all violations joined.
+func (h *Hub) validate(mc *MessagerContainer) error {
+	if h.opts.SkipValidators {
+		return nil
+	}
+	validators := getRegistrar().Validators
+	var errs []error
+	for _, name := range sortedNames(validators) {
+		if mc.GetMessager(name) == nil {
+			continue
+		}
+		for _, validate := range validators[name] {
+			if err := validate(mc); err != nil {
+				errs = append(errs, asLoadError(name, PhaseValidate, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ErrPendingDone is returned when committing a [PendingContainer] which
+// has already been committed or discarded.
+var ErrPendingDone = errors.New("pending container already committed or discarded")
+
+// PendingContainer is a loaded and post-processed [MessagerContainer]
+// staged by [Hub.Prepare], which is not taken into effect until committed.
+type PendingContainer struct {
+	hub  *Hub
+	mc   *MessagerContainer
+	done atomic.Bool
+}
+
+// Container returns the staged [MessagerContainer], which can be inspected
+// before committing.
+func (p *PendingContainer) Container() *MessagerContainer {
+	return p.mc
+}
+
+// Commit takes the staged container into effect. It can be called from any
+// goroutine, but only once, and returns [ErrPendingDone] if the pending
+// container has already been committed or discarded.
+func (p *PendingContainer) Commit() error {
+	if !p.done.CompareAndSwap(false, true) {
+		return ErrPendingDone
 	}
-	h.SetMessagerMap(messagerMap)
+	p.hub.metrics.loadSuccesses.Add(1)
+	p.hub.metrics.lastSuccessTime.Store(time.Now().UnixNano())
+	p.hub.setContainer(p.mc)
 	return nil
 }
 
+// Discard drops the staged container, so that it will never take effect.
+// It is a no-op if the pending container has already been committed or
+// discarded.
+func (p *PendingContainer) Discard() {
+	p.done.Store(true)
+}
+
+// Watch watches config files in the specified directory and the patch
+// directories in options, and reloads them with [Hub.LoadContext] when changed.
+// It blocks until ctx is done and then returns ctx.Err(). A failed reload
+// is reported to [HotReload.OnError] and keeps the current container.
+//
+// The profile of the current container when Watch is called, if any, is
+// applied to each reload as [Hub.LoadProfile] does, and its patch
+// directories are watched too.
+func (h *Hub) Watch(ctx context.Context, dir string, format format.Format, options ...load.Option) error {
+	profile := h.GetProfile()
+	profileOptions, err := h.profileOptions(profile, options)
+	if err != nil {
+		return err
+	}
+	hotReload := h.opts.HotReload
+	if hotReload == nil {
+		hotReload = &HotReload{}
+	}
+	backend := hotReload.Backend
+	if backend == nil {
+		backend = fswatch.Poll(time.Second)
+	}
+	debounce := hotReload.Debounce
+	if debounce <= 0 {
+		debounce = 500 * time.Millisecond
+	}
+	dirs := append([]string{dir}, load.ParseOptions(profileOptions...).PatchDirs...)
+	events, err := backend(ctx, dirs)
+	if err != nil {
+		return fmt.Errorf("failed to watch %v: %w", dirs, err)
+	}
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case _, ok := <-events:
+			if !ok {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				return fmt.Errorf("watch backend of %v stopped unexpectedly", dirs)
+			}
+			timer.Reset(debounce)
+		case <-timer.C:
+			if err := h.loadProfile(ctx, dir, format, profile, options...); err != nil && ctx.Err() == nil {
+				h.logger().Error("reload failed", LogKeyDir, dir, LogKeyError, err)
+				if hotReload.OnError != nil {
+					hotReload.OnError(err)
+				}
+			}
+		}
+	}
+}
+
+// loadMessagers loads all messagers in messagerMap. Messagers are loaded
+// in name order, or on a bounded worker pool if LoadConcurrency is greater
+// than 1. Either way, the error of the first failed messager in name order
+// is returned, or all errors joined in name order if CollectErrors is set.
+// Each messager is loaded with its LoadTimeout, and aborts when ctx is done.
+// Optional messagers whose config files are missing are not failures, but
+// returned as missing files in name order.
+func (h *Hub) loadMessagers(ctx context.Context, fsys fs.FS, messagerMap MessagerMap, dir string, format format.Format, opts *load.Options) ([]MissingFile, error) {
+	names := sortedNames(messagerMap)
+	missing := make([]*MissingFile, len(names))
+	loadOne := func(i int) error {
+		name := names[i]
+		observer := h.observer()
+		ctx := observer.OnMessagerStart(ctx, name)
+		ctx, cancel := h.loadTimeoutContext(ctx, name)
+		defer cancel()
+		msger := messagerMap[name]
+		mopts := opts.ParseMessagerOptionsByName(name)
+		// msger is a new instance dropped on failure, so it is fine that an
+		// abandoned load may still be filling it.
+		err := loadContext(ctx, name, mopts, func(mopts *load.MessagerOptions) error {
+			if fsys == nil {
+				return msger.Load(dir, format, mopts)
+			}
+			loader, ok := msger.(fsLoader)
+			if !ok {
+				return fmt.Errorf("loading from fs.FS: %w", ErrNotSupported)
+			}
+			// LoadFS overrides ReadFunc to read from fsys, so check ctx
+			// in fsys instead.
+			return loader.LoadFS(contextFS{ctx: ctx, fsys: fsys}, dir, format, mopts)
+		})
+		var stats *Stats
+		if ctx.Err() == nil {
+			// an aborted load may be still filling the stats of msger
+			copied := *msger.GetStats()
+			stats = &copied
+		}
+		observer.OnMessagerEnd(ctx, name, stats, err)
+		if err != nil {
+			var loadErr *LoadError
+			if h.isOptional(msger) && errors.Is(err, fs.ErrNotExist) && errors.As(err, &loadErr) {
+				missing[i] = &MissingFile{Messager: name, Path: loadErr.Path}
+				h.logger().Warn("optional config file missing", LogKeyMessager, name, LogKeyPath, loadErr.Path)
+				return nil
+			}
+			return asLoadError(name, PhaseLoad, err)
+		}
+		h.logMessagerLoaded(name, msger.GetStats())
+		return nil
+	}
+	collectMissing := func() []MissingFile {
+		var files []MissingFile
+		for _, file := range missing {
+			if file != nil {
+				files = append(files, *file)
+			}
+		}
+		return files
+	}
+	errs := make([]error, len(names))
+	concurrency := min(h.opts.LoadConcurrency, len(names))
+	if concurrency <= 1 {
+		for i := range names {
+			errs[i] = loadOne(i)
+			if errs[i] != nil && !h.opts.CollectErrors {
+				return nil, errs[i]
+			}
+		}
+		return collectMissing(), errors.Join(errs...)
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				errs[i] = loadOne(i)
+			}
+		}()
+	}
+	for i := range names {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	if h.opts.CollectErrors {
+		return collectMissing(), errors.Join(errs...)
+	}
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return collectMissing(), nil
+}
+
+// isOptional reports whether msger is optional, either by [WithOptional]
+// or by the "optional" label of its worksheet options.
+func (h *Hub) isOptional(msger Messager) bool {
+	if o, ok := msger.(optionalMessager); ok && o.optional() {
+		return true
+	}
+	return h.opts.Optional != nil && h.opts.Optional(msger.Name())
+}
+
+// loadTimeoutContext returns a copy of ctx which is done after the
+// LoadTimeout of the named messager, if specified.
+func (h *Hub) loadTimeoutContext(ctx context.Context, name string) (context.Context, context.CancelFunc) {
+	timeout := h.opts.LoadTimeout
+	if t, ok := h.opts.MessagerLoadTimeouts[name]; ok {
+		timeout = t
+	}
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	cause := fmt.Errorf("exceeded load timeout %v: %w", timeout, context.DeadlineExceeded)
+	return context.WithTimeoutCause(ctx, timeout, cause)
+}
+
+// sortedNames returns the keys of m in sorted order.
+func sortedNames[V any](m map[string]V) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortMessagersByDependencies returns messager names in topological order
+// of their dependencies, so that each messager is placed after all of its
+// dependencies. Independent messagers are placed in name order, so that the
+// order is deterministic.
+func sortMessagersByDependencies(messagerMap MessagerMap) ([]string, error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	states := make(map[string]int, len(messagerMap))
+	names := make([]string, 0, len(messagerMap))
+	var path []string
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch states[name] {
+		case visited:
+			return nil
+		case visiting:
+			cycle := append(path[slices.Index(path, name):], name)
+			return &LoadError{
+				Messager: name,
+				Phase:    PhaseProcessAfterLoadAll,
+				Err:      fmt.Errorf("dependency cycle: %s", strings.Join(cycle, " -> ")),
+			}
+		}
+		states[name] = visiting
+		path = append(path, name)
+		for _, dep := range messagerDependencies(messagerMap[name]) {
+			if _, ok := messagerMap[dep]; !ok {
+				return &LoadError{
+					Messager: name,
+					Phase:    PhaseProcessAfterLoadAll,
+					Err:      fmt.Errorf("depends on %s, which is not registered, filtered out or missing", dep),
+				}
+			}
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		states[name] = visited
+		names = append(names, name)
+		return nil
+	}
+	for _, name := range sortedNames(messagerMap) {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+	return names, nil
+}
+
 // Store stores protobuf messages to files in the specified directory and format.
 // Available formats: JSON, Bin, and Text.
 func (h *Hub) Store(dir string, format format.Format, options ...store.Option) error {
@@ -150,27 +1024,72 @@ func (h *Hub) mutableCheck() {
 	if interval == 0 {
 		interval = time.Minute
 	}
-	handler := h.opts.MutableCheck.OnMutate
-	if handler == nil {
-		handler = h.onMutateDefault
+	pacing := h.opts.MutableCheck.Pacing
+	if pacing == 0 {
+		pacing = time.Second
 	}
 	for {
-		time.Sleep(interval)
+		if !h.sleep(interval) {
+			return
+		}
 		messagerMap := h.GetMessagerMap()
-		for name, msger := range messagerMap {
-			time.Sleep(time.Second)
-			if !proto.Equal(msger.originalMessage(), msger.Message()) {
-				handler(name, msger.originalMessage(), msger.Message())
+		for _, name := range sortedNames(messagerMap) {
+			if pacing > 0 && !h.sleep(pacing) {
+				return
+			}
+			if report := checkMutation(name, messagerMap[name]); report != nil {
+				h.onMutate(report)
 			}
 		}
 	}
 }
 
-func (h *Hub) onMutateDefault(name string, original, current proto.Message) {
-	text, _ := udiff.UnifiedDiff(original, current)
-	fmt.Fprintf(os.Stderr,
-		"==== %s DIFF BEGIN ====\n%s==== %s DIFF END ====\n",
-		name, text, name)
+// sleep pauses for duration d, and reports false if the hub is closed
+// meanwhile.
+func (h *Hub) sleep(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-h.done:
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// CheckMutations checks all messagers at once without pacing, and returns
+// the reports of mutated ones in name order, e.g. for tests.
+//
+// NOTE: only messagers loaded with [WithMutableCheck] enabled are checked,
+// as the original messages are backed up only then.
+func (h *Hub) CheckMutations() []MutationReport {
+	var reports []MutationReport
+	messagerMap := h.GetMessagerMap()
+	for _, name := range sortedNames(messagerMap) {
+		if report := checkMutation(name, messagerMap[name]); report != nil {
+			reports = append(reports, *report)
+		}
+	}
+	return reports
+}
+
+// onMutate reports the mutation to the handlers of MutableCheck, or logs it
+// if no handler specified.
+func (h *Hub) onMutate(report *MutationReport) {
+	check := h.opts.MutableCheck
+	logger := h.logger()
+	switch {
+	case check.OnMutationReport != nil:
+		logger.Warn("mutation detected", LogKeyMessager, report.Messager, LogKeyKeys, report.Keys)
+		check.OnMutationReport(report)
+	case check.OnMutate != nil:
+		logger.Warn("mutation detected", LogKeyMessager, report.Messager, LogKeyKeys, report.Keys)
+		check.OnMutate(report.Messager, report.Original, report.Current)
+	case h.opts.Logger != nil:
+		logger.Warn("mutation detected", LogKeyMessager, report.Messager, LogKeyKeys, report.Keys, LogKeyChanges, report.changeLines())
+	default:
+		fmt.Fprint(os.Stderr, report.String())
+	}
 }
 
 type ctxKey struct{}
@@ -204,6 +1123,29 @@ func (h *Hub) GetMessager(name string) Messager {
 func (h *Hub) GetLastLoadedTime() time.Time {
 	return h.mc.Load().GetLastLoadedTime()
 }
+
+// GetMissingFiles returns the missing config files of optional messagers
+// in the current container.
+func (h *Hub) GetMissingFiles() []MissingFile {
+	return h.mc.Load().GetMissingFiles()
+}
+
+// GetProfile returns the profile of the current container.
+func (h *Hub) GetProfile() string {
+	return h.mc.Load().GetProfile()
+}
+
+// GetGenerations returns the generations and loaded times of the previous
+// messager containers kept for [Hub.Rollback], the latest last.
+func (h *Hub) GetGenerations() []Generation {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	generations := make([]Generation, 0, len(h.history))
+	for _, mc := range h.history {
+		generations = append(generations, Generation{ID: mc.GetGeneration(), LoadedTime: mc.GetLastLoadedTime()})
+	}
+	return generations
+}
 {{ range . }}
 func (h *Hub) Get{{ . }}() *{{ . }} {
 	return h.mc.Load().Get{{ . }}()
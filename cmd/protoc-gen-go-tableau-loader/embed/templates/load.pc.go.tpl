@@ -0,0 +1,238 @@
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/tableauio/tableau/format"
+	"github.com/tableauio/tableau/load"
+	"google.golang.org/protobuf/proto"
+)
+
+// LoadPhase is the phase of loading in which a messager failed.
+type LoadPhase string
+
+const (
+	// PhaseRead means failed to read a config file.
+	PhaseRead LoadPhase = "read"
+	// PhaseParse means failed to parse a config file.
+	PhaseParse LoadPhase = "parse"
+	// PhasePatch means failed to patch a config by patch files.
+	PhasePatch LoadPhase = "patch"
+	// PhaseLoad means a custom messager's Load failed.
+	PhaseLoad LoadPhase = "load"
+	// PhaseProcessAfterLoad means failed to process a messager after it
+	// is loaded, e.g. building ordered maps and indexes.
+	PhaseProcessAfterLoad LoadPhase = "processAfterLoad"
+	// PhaseProcessAfterLoadAll means failed to process a messager after all
+	// messagers are loaded, including resolving messager dependencies.
+	PhaseProcessAfterLoadAll LoadPhase = "ProcessAfterLoadAll"
+	// PhaseCheckRefer means a field with refer prop references a key not
+	// existing in the referred messager.
+	PhaseCheckRefer LoadPhase = "checkRefer"
+	// PhaseValidate means a registered validator failed.
+	PhaseValidate LoadPhase = "validate"
+)
+
+// LoadError is the structured error of a failed messager, which can be
+// inspected by [errors.As].
+type LoadError struct {
+	Messager string    // messager name
+	Phase    LoadPhase // phase in which the messager failed
+	Path     string    // config file path, empty if not related to a file
+	Err      error     // the cause
+}
+
+func (e *LoadError) Error() string {
+	if e.Path != "" {
+		return fmt.Sprintf("failed to load %s: %s %s: %v", e.Messager, e.Phase, e.Path, e.Err)
+	}
+	return fmt.Sprintf("failed to load %s: %s: %v", e.Messager, e.Phase, e.Err)
+}
+
+func (e *LoadError) Unwrap() error {
+	return e.Err
+}
+
+// asLoadError returns err as is if it is a [*LoadError], or wraps it as a
+// [*LoadError] of the given messager and phase.
+func asLoadError(name string, phase LoadPhase, err error) error {
+	if _, ok := err.(*LoadError); ok {
+		return err
+	}
+	return &LoadError{Messager: name, Phase: phase, Err: err}
+}
+
+// loadMessagerInDir loads message's content in the given dir, based on
+// format and messager options, and fills the read, unmarshal and patch
+// timings, file paths and byte size into stats. Failures are reported as
+// [*LoadError] with the failed phase and file path.
+func loadMessagerInDir(msg proto.Message, dir string, fmt format.Format, opts *load.MessagerOptions, stats *Stats) error {
+	name := string(msg.ProtoReflect().Descriptor().Name())
+	var mopts load.MessagerOptions
+	if opts != nil {
+		mopts = *opts
+	}
+	mainPath := mopts.Path
+	if mainPath == "" {
+		mainPath = filepath.Join(dir, configFilename(name, fmt))
+	}
+	var loadErr *LoadError
+	var lastPath string
+	var loadDuration time.Duration
+	readFunc := mopts.GetReadFunc()
+	mopts.ReadFunc = func(path string) ([]byte, error) {
+		start := time.Now()
+		content, err := readFunc(path)
+		stats.ReadDuration += time.Since(start)
+		stats.ByteSize += len(content)
+		if err != nil && loadErr == nil {
+			loadErr = &LoadError{Messager: name, Phase: PhaseRead, Path: path}
+		}
+		return content, err
+	}
+	loadFunc := mopts.GetLoadFunc()
+	mopts.LoadFunc = func(msg proto.Message, path string, fmt format.Format, opts *load.MessagerOptions) error {
+		lastPath = path
+		stats.Paths = append(stats.Paths, path)
+		if path != mainPath {
+			stats.PatchPaths = append(stats.PatchPaths, path)
+		}
+		start := time.Now()
+		err := loadFunc(msg, path, fmt, opts)
+		loadDuration += time.Since(start)
+		if err != nil && loadErr == nil {
+			loadErr = &LoadError{Messager: name, Phase: PhaseParse, Path: path}
+		}
+		return err
+	}
+	start := time.Now()
+	err := load.LoadMessagerInDir(msg, dir, fmt, &mopts)
+	if len(stats.Paths) != 0 {
+		stats.UnmarshalDuration = loadDuration - stats.ReadDuration
+		if len(stats.PatchPaths) != 0 {
+			stats.PatchDuration = time.Since(start) - loadDuration
+		}
+	} else {
+		// input formats are parsed without read and load funcs
+		stats.UnmarshalDuration = time.Since(start)
+	}
+	if err == nil {
+		stats.EntryCount = recordCount(msg)
+		return nil
+	}
+	if loadErr == nil {
+		if format.IsInputFormat(fmt) {
+			loadErr = &LoadError{Messager: name, Phase: PhaseParse}
+		} else {
+			// all files are loaded, so it failed in patching
+			loadErr = &LoadError{Messager: name, Phase: PhasePatch, Path: lastPath}
+		}
+	}
+	loadErr.Err = err
+	return loadErr
+}
+
+// fsMessagerOptions returns a copy of opts which reads the config file and
+// patch files of the named messager in the given dir of fsys. Path,
+// PatchPaths and PatchDirs are all resolved inside fsys.
+func fsMessagerOptions(fsys fs.FS, name, dir string, fmt format.Format, opts *load.MessagerOptions) (*load.MessagerOptions, error) {
+	if format.IsInputFormat(fmt) {
+		return nil, &LoadError{Messager: name, Phase: PhaseRead, Err: errors.New("input formats are not supported by fs.FS")}
+	}
+	var mopts load.MessagerOptions
+	if opts != nil {
+		mopts = *opts
+	}
+	mopts.ReadFunc = func(filename string) ([]byte, error) {
+		return fs.ReadFile(fsys, filepath.ToSlash(filename))
+	}
+	if mopts.Path == "" {
+		mopts.Path = path.Join(dir, configFilename(name, fmt))
+	}
+	if mopts.PatchPaths == nil && len(mopts.PatchDirs) != 0 {
+		// tableau checks existence of patch files in the OS file system,
+		// so resolve them inside fsys in advance.
+		mopts.PatchPaths = []string{}
+		for _, patchDir := range mopts.PatchDirs {
+			patchPath := path.Join(patchDir, configFilename(name, fmt))
+			if _, err := fs.Stat(fsys, patchPath); err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					continue
+				}
+				return nil, &LoadError{Messager: name, Phase: PhaseRead, Path: patchPath, Err: err}
+			}
+			mopts.PatchPaths = append(mopts.PatchPaths, patchPath)
+		}
+	}
+	return &mopts, nil
+}
+
+// loadContext runs loadFunc with a copy of opts whose ReadFunc checks ctx
+// before reading each file, and returns as soon as ctx is done. As the
+// abandoned loadFunc may still be running then, loadFunc must load into
+// values not shared with the caller, or the caller must discard them after
+// ctx is done.
+func loadContext(ctx context.Context, name string, opts *load.MessagerOptions, loadFunc func(opts *load.MessagerOptions) error) error {
+	if err := context.Cause(ctx); err != nil {
+		return &LoadError{Messager: name, Phase: PhaseLoad, Err: err}
+	}
+	var mopts load.MessagerOptions
+	if opts != nil {
+		mopts = *opts
+	}
+	readFunc := mopts.GetReadFunc()
+	mopts.ReadFunc = func(path string) ([]byte, error) {
+		if err := context.Cause(ctx); err != nil {
+			return nil, err
+		}
+		return readFunc(path)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- loadFunc(&mopts)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return &LoadError{Messager: name, Phase: PhaseLoad, Err: context.Cause(ctx)}
+	}
+}
+
+// contextFS is an [fs.FS] which checks ctx before each access to fsys, so
+// that loading from it stops reading files when ctx is done.
+type contextFS struct {
+	ctx  context.Context
+	fsys fs.FS
+}
+
+func (c contextFS) Open(name string) (fs.File, error) {
+	if err := context.Cause(c.ctx); err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return c.fsys.Open(name)
+}
+
+func (c contextFS) ReadFile(name string) ([]byte, error) {
+	if err := context.Cause(c.ctx); err != nil {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: err}
+	}
+	return fs.ReadFile(c.fsys, name)
+}
+
+func (c contextFS) Stat(name string) (fs.FileInfo, error) {
+	if err := context.Cause(c.ctx); err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+	}
+	return fs.Stat(c.fsys, name)
+}
+
+// configFilename returns the config file name of the named messager in
+// the specified format, e.g.: "ItemConf.json".
+func configFilename(name string, fmt format.Format) string {
+	return name + format.Format2Ext(fmt)
+}
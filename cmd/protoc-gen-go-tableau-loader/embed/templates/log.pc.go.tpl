@@ -0,0 +1,50 @@
+import (
+	"log/slog"
+)
+
+// Attribute keys of the structured events logged by the hub, see
+// [WithLogger].
+const (
+	LogKeyMessager          = "messager"          // messager name
+	LogKeyDir               = "dir"               // config directory
+	LogKeyFormat            = "format"            // config format
+	LogKeyPath              = "path"              // config file path
+	LogKeyPatchPaths        = "patchPaths"        // applied patch file paths
+	LogKeyCount             = "count"             // number of messagers
+	LogKeyDuration          = "duration"          // time consuming
+	LogKeyReadDuration      = "readDuration"      // time consuming of reading files
+	LogKeyUnmarshalDuration = "unmarshalDuration" // time consuming of unmarshaling files
+	LogKeyPatchDuration     = "patchDuration"     // time consuming of merging patches
+	LogKeyByteSize          = "byteSize"          // total size of read files
+	LogKeyEntryCount        = "entryCount"        // number of entries of first-level maps and lists
+	LogKeyGeneration        = "generation"        // generation of messager container
+	LogKeyKeys              = "keys"              // top-level fields and map keys of mutated message
+	LogKeyChanges           = "changes"           // changed field paths of mutated message
+	LogKeyError             = "error"             // the cause of failure
+)
+
+// logger returns the logger specified by [WithLogger], or a logger
+// discarding all events if not specified.
+func (h *Hub) logger() *slog.Logger {
+	if h.opts == nil || h.opts.Logger == nil {
+		return slog.New(slog.DiscardHandler)
+	}
+	return h.opts.Logger
+}
+
+// logMessagerLoaded logs the timings and patches of a loaded messager.
+func (h *Hub) logMessagerLoaded(name string, stats *Stats) {
+	logger := h.logger()
+	if len(stats.PatchPaths) != 0 {
+		logger.Info("patch applied", LogKeyMessager, name, LogKeyPatchPaths, stats.PatchPaths)
+	}
+	logger.Debug("messager loaded",
+		LogKeyMessager, name,
+		LogKeyDuration, stats.Duration,
+		LogKeyReadDuration, stats.ReadDuration,
+		LogKeyUnmarshalDuration, stats.UnmarshalDuration,
+		LogKeyPatchDuration, stats.PatchDuration,
+		LogKeyByteSize, stats.ByteSize,
+		LogKeyEntryCount, stats.EntryCount,
+	)
+}
@@ -1,4 +1,5 @@
 import (
+	"io/fs"
 	"sync"
 	"time"
 
@@ -31,8 +32,63 @@ type Messager interface {
 	enableBackup()
 }
 
+// messageLoader is implemented by messagers which can be loaded from a
+// message instead of files, e.g. all generated messagers.
+type messageLoader interface {
+	// loadMessage fills message from msg, which is taken over.
+	loadMessage(msg proto.Message) error
+}
+
+// fsLoader is implemented by messagers which can be loaded from an
+// [fs.FS], e.g. all generated messagers.
+type fsLoader interface {
+	// LoadFS fills message from file in the specified directory of fsys
+	// and format.
+	LoadFS(fsys fs.FS, dir string, fmt format.Format, opts *load.MessagerOptions) error
+}
+
+// dependent is implemented by messagers whose ProcessAfterLoadAll depends
+// on other messagers.
+type dependent interface {
+	// Dependencies returns the names of messagers whose ProcessAfterLoadAll
+	// must be invoked before this messager's.
+	Dependencies() []string
+}
+
+// optionalMessager is implemented by generated messagers labeled "optional"
+// in worksheet options, which tolerate a missing config file.
+type optionalMessager interface {
+	optional() bool
+}
+
+// referChecker is implemented by generated messagers with fields of refer
+// prop.
+type referChecker interface {
+	// checkRefer checks that fields with refer prop reference existing keys
+	// of the referred messagers.
+	checkRefer(mc *MessagerContainer) error
+}
+
+// messagerDependencies returns the dependencies of msger if it implements
+// Dependencies() []string, or nil otherwise.
+func messagerDependencies(msger Messager) []string {
+	if d, ok := msger.(dependent); ok {
+		return d.Dependencies()
+	}
+	return nil
+}
+
 type Stats struct {
-	Duration time.Duration // total load time consuming.
+	Duration                    time.Duration // total load time consuming.
+	ReadDuration                time.Duration // time consuming of reading files.
+	UnmarshalDuration           time.Duration // time consuming of unmarshaling (or parsing input format) files.
+	PatchDuration               time.Duration // time consuming of merging patches.
+	ProcessAfterLoadDuration    time.Duration // time consuming of processAfterLoad, e.g. building ordered maps and indexes.
+	ProcessAfterLoadAllDuration time.Duration // time consuming of ProcessAfterLoadAll, not included in Duration.
+	Paths                       []string      // all loaded file paths, including patch files.
+	PatchPaths                  []string      // applied patch file paths.
+	ByteSize                    int           // total size of read files.
+	EntryCount                  int           // number of entries of first-level maps and lists.
 }
 
 type UnimplementedMessager struct {
@@ -52,6 +108,10 @@ func (x *UnimplementedMessager) Load(dir string, format format.Format, opts *loa
 	return nil
 }
 
+func (x *UnimplementedMessager) LoadFS(fsys fs.FS, dir string, format format.Format, opts *load.MessagerOptions) error {
+	return nil
+}
+
 func (x *UnimplementedMessager) Store(dir string, format format.Format, options ...store.Option) error {
 	return nil
 }
@@ -82,13 +142,20 @@ func (x *UnimplementedMessager) originalMessage() proto.Message {
 
 type MessagerMap = map[string]Messager
 type MessagerGenerator = func() Messager
+
+// Validator validates a loaded messager container before it takes effect.
+type Validator = func(mc *MessagerContainer) error
+
 type Registrar struct {
 	Generators map[string]MessagerGenerator
+	// Validators maps each messager name to its validators.
+	Validators map[string][]Validator
 }
 
 func NewRegistrar() *Registrar {
 	return &Registrar{
 		Generators: map[string]MessagerGenerator{},
+		Validators: map[string][]Validator{},
 	}
 }
 
@@ -99,6 +166,10 @@ func (r *Registrar) Register(gen MessagerGenerator) {
 	r.Generators[gen().Name()] = gen
 }
 
+func (r *Registrar) RegisterValidator(name string, validator Validator) {
+	r.Validators[name] = append(r.Validators[name], validator)
+}
+
 var registrarSingleton *Registrar
 var once sync.Once
 
@@ -112,3 +183,16 @@ func getRegistrar() *Registrar {
 func Register(gen MessagerGenerator) {
 	getRegistrar().Register(gen)
 }
+
+// RegisterValidator registers a validator of messager T, which is run after
+// ProcessAfterLoadAll and before the loaded container takes effect. Any
+// validator failure aborts the loading and keeps the current container.
+//
+// NOTE: the validator is skipped if messager T is not loaded, e.g. filtered
+// out by [Filter].
+func RegisterValidator[T Messager](validate func(T, *MessagerContainer) error) {
+	var t T
+	getRegistrar().RegisterValidator(t.Name(), func(mc *MessagerContainer) error {
+		return validate(GetMessager[T](mc.GetMessagerMap()), mc)
+	})
+}
@@ -1,10 +1,23 @@
 import (
+	"sync"
 	"time"
 )
 
 type MessagerContainer struct {
 	messagerMap MessagerMap
 	loadedTime  time.Time
+	generation  uint64
+	// profile loaded by Hub.LoadProfile, empty if loaded without profile
+	profile string
+	// config files of optional messagers missing when loading
+	missingFiles []MissingFile
+	// versions of config files loaded from a source, keyed by full path
+	sourceVersions map[string]string
+	// load options of messagers loaded from a source
+	sourceOptions map[string]sourceOptions
+	// metrics of messagers, computed once on demand
+	messagerMetricsOnce sync.Once
+	messagerMetrics     []MessagerMetrics
 	// all messagers as fields for fast access
 {{ range . }}	{{ toLowerCamel . }} *{{ . }}
 {{ end }}}
@@ -28,6 +41,37 @@ func (mc *MessagerContainer) GetMessager(name string) Messager {
 func (mc *MessagerContainer) GetLastLoadedTime() time.Time {
 	return mc.loadedTime
 }
+
+// GetGeneration returns the generation of this container set to the hub,
+// or 0 if it has not been set.
+func (mc *MessagerContainer) GetGeneration() uint64 {
+	return mc.generation
+}
+
+// GetMissingFiles returns the missing config files of optional messagers,
+// which are left out of this container, in messager name order.
+func (mc *MessagerContainer) GetMissingFiles() []MissingFile {
+	return mc.missingFiles
+}
+
+// GetProfile returns the profile which this container is loaded with by
+// [Hub.LoadProfile], or empty if it is loaded without profile.
+func (mc *MessagerContainer) GetProfile() string {
+	return mc.profile
+}
+
+// MissingFile describes a missing config file of an optional messager.
+type MissingFile struct {
+	Messager string // messager name
+	Path     string // missing config file path
+}
+
+// Generation describes a messager container kept by the hub.
+type Generation struct {
+	ID         uint64    // generation number
+	LoadedTime time.Time // time when the container was loaded
+}
+
 // Auto-generated getters below
 {{ range . }}
 func (mc *MessagerContainer) Get{{ . }}() *{{ . }} {
@@ -0,0 +1,142 @@
+import (
+	"expvar"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// hubMetrics records the counters of a hub.
+type hubMetrics struct {
+	loadSuccesses   atomic.Uint64
+	loadFailures    atomic.Uint64
+	rollbacks       atomic.Uint64
+	lastSuccessTime atomic.Int64 // unix nanoseconds, 0 if never succeeded
+}
+
+// Metrics is a snapshot of the health metrics of a hub's config loading.
+type Metrics struct {
+	// LoadSuccesses is the number of loaded messager containers committed
+	// into effect, excluding the ones set by [Hub.SetMessagerMap].
+	LoadSuccesses uint64 `json:"loadSuccesses"`
+	// LoadFailures is the number of messager containers failed to prepare.
+	LoadFailures uint64 `json:"loadFailures"`
+	// Rollbacks is the number of rollbacks by [Hub.Rollback].
+	Rollbacks uint64 `json:"rollbacks"`
+	// LastSuccessTime is the time of the last successful load, zero if
+	// never succeeded.
+	LastSuccessTime time.Time `json:"lastSuccessTime"`
+	// SinceLastSuccess is the time elapsed since LastSuccessTime, zero if
+	// never succeeded.
+	SinceLastSuccess time.Duration `json:"sinceLastSuccess"`
+	// Messagers are metrics of messagers in the current container, sorted
+	// by name.
+	Messagers []MessagerMetrics `json:"messagers"`
+}
+
+// MessagerMetrics is a snapshot of the metrics of a loaded messager.
+type MessagerMetrics struct {
+	Name         string        `json:"name"`
+	LoadDuration time.Duration `json:"loadDuration"` // from Stats.Duration
+	ByteSize     int           `json:"byteSize"`     // size of the message in protobuf wire format
+	RecordCount  int           `json:"recordCount"`  // number of entries of first-level maps and lists
+}
+
+// GetMetrics returns a snapshot of the health metrics of config loading.
+func (h *Hub) GetMetrics() *Metrics {
+	metrics := &Metrics{
+		LoadSuccesses: h.metrics.loadSuccesses.Load(),
+		LoadFailures:  h.metrics.loadFailures.Load(),
+		Rollbacks:     h.metrics.rollbacks.Load(),
+		Messagers:     h.mc.Load().getMessagerMetrics(),
+	}
+	if nsec := h.metrics.lastSuccessTime.Load(); nsec != 0 {
+		metrics.LastSuccessTime = time.Unix(0, nsec)
+		metrics.SinceLastSuccess = time.Since(metrics.LastSuccessTime)
+	}
+	return metrics
+}
+
+// PublishExpvar publishes the health metrics of config loading as an
+// [expvar] variable with the given name, e.g.: "tableau_loader".
+//
+// NOTE: it panics if the name is already published, as [expvar.Publish]
+// does.
+func (h *Hub) PublishExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() any { return h.GetMetrics() }))
+}
+
+// MetricsHandler returns an [http.Handler] which exposes the health metrics
+// of config loading in the OpenMetrics text format.
+func (h *Hub) MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		metrics := h.GetMetrics()
+		w.Header().Set("Content-Type", "application/openmetrics-text; version=1.0.0; charset=utf-8")
+		fmt.Fprintln(w, "# TYPE tableau_loader_load_successes counter")
+		fmt.Fprintln(w, "# HELP tableau_loader_load_successes Number of loaded messager containers committed into effect.")
+		fmt.Fprintf(w, "tableau_loader_load_successes_total %d\n", metrics.LoadSuccesses)
+		fmt.Fprintln(w, "# TYPE tableau_loader_load_failures counter")
+		fmt.Fprintln(w, "# HELP tableau_loader_load_failures Number of messager containers failed to prepare.")
+		fmt.Fprintf(w, "tableau_loader_load_failures_total %d\n", metrics.LoadFailures)
+		fmt.Fprintln(w, "# TYPE tableau_loader_rollbacks counter")
+		fmt.Fprintln(w, "# HELP tableau_loader_rollbacks Number of rollbacks to previous messager containers.")
+		fmt.Fprintf(w, "tableau_loader_rollbacks_total %d\n", metrics.Rollbacks)
+		fmt.Fprintln(w, "# TYPE tableau_loader_seconds_since_last_success gauge")
+		fmt.Fprintln(w, "# HELP tableau_loader_seconds_since_last_success Seconds since the last successful load.")
+		if !metrics.LastSuccessTime.IsZero() {
+			fmt.Fprintf(w, "tableau_loader_seconds_since_last_success %g\n", metrics.SinceLastSuccess.Seconds())
+		}
+		fmt.Fprintln(w, "# TYPE tableau_loader_messager_load_duration_seconds gauge")
+		fmt.Fprintln(w, "# HELP tableau_loader_messager_load_duration_seconds Load duration of each messager.")
+		for _, m := range metrics.Messagers {
+			fmt.Fprintf(w, "tableau_loader_messager_load_duration_seconds{messager=%q} %g\n", m.Name, m.LoadDuration.Seconds())
+		}
+		fmt.Fprintln(w, "# TYPE tableau_loader_messager_bytes gauge")
+		fmt.Fprintln(w, "# HELP tableau_loader_messager_bytes Size of each messager in protobuf wire format.")
+		for _, m := range metrics.Messagers {
+			fmt.Fprintf(w, "tableau_loader_messager_bytes{messager=%q} %d\n", m.Name, m.ByteSize)
+		}
+		fmt.Fprintln(w, "# TYPE tableau_loader_messager_records gauge")
+		fmt.Fprintln(w, "# HELP tableau_loader_messager_records Number of entries of first-level maps and lists of each messager.")
+		for _, m := range metrics.Messagers {
+			fmt.Fprintf(w, "tableau_loader_messager_records{messager=%q} %d\n", m.Name, m.RecordCount)
+		}
+		fmt.Fprintln(w, "# EOF")
+	})
+}
+
+// getMessagerMetrics returns the metrics of messagers in the container,
+// which are computed once on the first call.
+func (mc *MessagerContainer) getMessagerMetrics() []MessagerMetrics {
+	mc.messagerMetricsOnce.Do(func() {
+		for _, name := range sortedNames(mc.messagerMap) {
+			msger := mc.messagerMap[name]
+			metrics := MessagerMetrics{Name: name, LoadDuration: msger.GetStats().Duration}
+			if msg := msger.Message(); msg != nil {
+				metrics.ByteSize = proto.Size(msg)
+				metrics.RecordCount = recordCount(msg)
+			}
+			mc.messagerMetrics = append(mc.messagerMetrics, metrics)
+		}
+	})
+	return mc.messagerMetrics
+}
+
+// recordCount returns the number of entries of all first-level map and
+// list fields of msg.
+func recordCount(msg proto.Message) int {
+	count := 0
+	msg.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsMap():
+			count += v.Map().Len()
+		case fd.IsList():
+			count += v.List().Len()
+		}
+		return true
+	})
+	return count
+}
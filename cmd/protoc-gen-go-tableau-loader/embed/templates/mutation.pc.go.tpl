@@ -0,0 +1,117 @@
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tableauio/loader/pkg/udiff"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// MutationReport describes a messager whose loaded message is mutated.
+type MutationReport struct {
+	Messager string        // messager name
+	Original proto.Message // original message when loaded
+	Current  proto.Message // current mutated message
+	// Keys are the top-level fields involved, with map keys if they are
+	// maps, e.g.: "item_map[1]", or `item_map["apple"]` for string keys.
+	Keys []string
+	// Changes are the changed fields in path order.
+	Changes []FieldChange
+}
+
+// FieldChange describes a changed field of a mutated message.
+type FieldChange struct {
+	Path string // field path, e.g.: "item_map[1].name"
+	Old  any    // old value, or nil if added
+	New  any    // new value, or nil if removed
+}
+
+func (c FieldChange) String() string {
+	return fmt.Sprintf("%s: %s -> %s", c.Path, formatFieldValue(c.Old), formatFieldValue(c.New))
+}
+
+func (r *MutationReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "==== %s MUTATED: %s ====\n", r.Messager, strings.Join(r.Keys, ", "))
+	for _, line := range r.changeLines() {
+		fmt.Fprintf(&sb, "%s\n", line)
+	}
+	return sb.String()
+}
+
+// changeLines returns the changes formatted line by line.
+func (r *MutationReport) changeLines() []string {
+	lines := make([]string, len(r.Changes))
+	for i, change := range r.Changes {
+		lines[i] = change.String()
+	}
+	return lines
+}
+
+func formatFieldValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "<none>"
+	case string:
+		return fmt.Sprintf("%q", v)
+	case proto.Message:
+		return fmt.Sprintf("{%v}", v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// checkMutation returns the report if msger is mutated, or nil if not
+// mutated or its original message is not backed up. Mutations not reported
+// by [udiff.Diff], e.g.: of unknown fields, are ignored.
+func checkMutation(name string, msger Messager) *MutationReport {
+	original := msger.originalMessage()
+	if original == nil || !original.ProtoReflect().IsValid() {
+		return nil
+	}
+	current := msger.Message()
+	if proto.Equal(original, current) {
+		return nil
+	}
+	report := &MutationReport{Messager: name, Original: original, Current: current}
+	for _, change := range udiff.Diff(original, current) {
+		field := change.Path.ValueField()
+		report.Changes = append(report.Changes, FieldChange{
+			Path: change.Path.String(),
+			Old:  fieldValue(field, change.Old),
+			New:  fieldValue(field, change.New),
+		})
+		// top-level field, with map key if it is a map
+		top := change.Path[:1]
+		if len(change.Path) > 1 && change.Path[1].Kind == udiff.MapKeyStep {
+			top = change.Path[:2]
+		}
+		if key := top.String(); !slices.Contains(report.Keys, key) {
+			report.Keys = append(report.Keys, key)
+		}
+	}
+	if len(report.Changes) == 0 {
+		return nil
+	}
+	return report
+}
+
+// fieldValue converts v of field fd to a Go value: messages as
+// [proto.Message], enums as value names if defined, scalars as is, and nil
+// if v is invalid.
+func fieldValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) any {
+	switch {
+	case !v.IsValid():
+		return nil
+	case fd.Message() != nil:
+		return v.Message().Interface()
+	case fd.Enum() != nil:
+		if evd := fd.Enum().Values().ByNumber(v.Enum()); evd != nil {
+			return string(evd.Name())
+		}
+		return int32(v.Enum())
+	default:
+		return v.Interface()
+	}
+}
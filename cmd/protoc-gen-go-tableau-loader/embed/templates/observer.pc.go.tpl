@@ -0,0 +1,68 @@
+import (
+	"context"
+	"time"
+)
+
+// LoadObserver observes the lifecycle of each load of the hub, e.g. to
+// bridge to a tracer. It is invoked by all loads, including [Hub.Load],
+// [Hub.Reload], [Hub.Watch], [Hub.LoadSource] and [Hub.PreparePatch].
+//
+// The start hooks return a context derived from the given one, e.g. with a
+// tracing span, which is used for the loading and passed to the paired end
+// hooks.
+//
+// NOTE: OnMessagerStart and OnMessagerEnd may be called concurrently if
+// LoadConcurrency is greater than 1.
+type LoadObserver interface {
+	// OnLoadStart is called before loading, with the sorted names of
+	// messagers to be loaded.
+	OnLoadStart(ctx context.Context, names []string) context.Context
+	// OnMessagerStart is called before loading the named messager, with the
+	// context returned by OnLoadStart.
+	OnMessagerStart(ctx context.Context, name string) context.Context
+	// OnMessagerEnd is called after loading the named messager, with the
+	// context returned by OnMessagerStart, a copy of its stats and the
+	// failure if any. Stats is nil if the loading is aborted as the context
+	// is done.
+	OnMessagerEnd(ctx context.Context, name string, stats *Stats, err error)
+	// OnPostProcess is called after post-processing all messagers, i.e.
+	// ProcessAfterLoadAll, refer checks and validators, with the context
+	// returned by OnLoadStart, the time consuming and the failure if any.
+	OnPostProcess(ctx context.Context, duration time.Duration, err error)
+	// OnLoadEnd is called after loading, with the context returned by
+	// OnLoadStart, the total time consuming and the failure if any. The
+	// loaded container is not in effect until committed.
+	OnLoadEnd(ctx context.Context, duration time.Duration, err error)
+	// OnCommit is called after a container takes effect, with its
+	// generation, either committed or rolled back to by [Hub.Rollback].
+	OnCommit(generation uint64)
+}
+
+// UnimplementedLoadObserver implements all methods of [LoadObserver] as
+// no-op, which can be embedded to observe only some of them.
+type UnimplementedLoadObserver struct{}
+
+func (UnimplementedLoadObserver) OnLoadStart(ctx context.Context, names []string) context.Context {
+	return ctx
+}
+
+func (UnimplementedLoadObserver) OnMessagerStart(ctx context.Context, name string) context.Context {
+	return ctx
+}
+
+func (UnimplementedLoadObserver) OnMessagerEnd(ctx context.Context, name string, stats *Stats, err error) {}
+
+func (UnimplementedLoadObserver) OnPostProcess(ctx context.Context, duration time.Duration, err error) {}
+
+func (UnimplementedLoadObserver) OnLoadEnd(ctx context.Context, duration time.Duration, err error) {}
+
+func (UnimplementedLoadObserver) OnCommit(generation uint64) {}
+
+// observer returns the observer specified by [WithLoadObserver], or a no-op
+// observer if not specified.
+func (h *Hub) observer() LoadObserver {
+	if h.opts == nil || h.opts.LoadObserver == nil {
+		return UnimplementedLoadObserver{}
+	}
+	return h.opts.LoadObserver
+}
@@ -0,0 +1,84 @@
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// PatchFunc applies patch to msg and returns the patched result as a new
+// message, e.g.: udiff.ApplyJSONPatch and udiff.ApplyMergePatch.
+type PatchFunc func(msg proto.Message, patch []byte) (proto.Message, error)
+
+// PreparePatch applies patch by patchFunc to the message of the named
+// messager in the current container, and stages a new container in which
+// the named messager is replaced by a new one loaded with the patched
+// message, without taking it into effect. Call [PendingContainer.Commit] to
+// take it into effect, or [PendingContainer.Discard] to drop it.
+//
+// As [Hub.Reload] does, the other loaded messager instances are reused,
+// except that messagers depending on the named one are created anew with
+// clones of their current messages, and messagers without loaded data, e.g.
+// custom messagers, are created anew without loading. ProcessAfterLoadAll
+// is run only on the new instances, and then references are checked and
+// validators are run on the whole container. It is logged, observed and
+// counted in metrics as the other loads are.
+func (h *Hub) PreparePatch(name string, patch []byte, patchFunc PatchFunc) (_ *PendingContainer, err error) {
+	current := h.mc.Load()
+	msger := current.GetMessagerMap()[name]
+	if msger == nil || msger.Message() == nil {
+		return nil, fmt.Errorf("messager %s: %w", name, ErrNotFound)
+	}
+	messagerMap, loadMap := h.reuseMessagers(func(n string) bool { return n == name })
+	for n := range loadMap {
+		if _, ok := current.GetMessagerMap()[n]; !ok {
+			// leave out messagers missing in the current container
+			delete(messagerMap, n)
+			delete(loadMap, n)
+		}
+	}
+	ctx, end := h.startLoad(context.Background(), loadMap, LogKeyMessager, name)
+	defer func() { end(err) }()
+	patched, err := patchFunc(msger.Message(), patch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to patch messager %s: %w", name, err)
+	}
+	if got, want := patched.ProtoReflect().Descriptor().FullName(), msger.Message().ProtoReflect().Descriptor().FullName(); got != want {
+		return nil, fmt.Errorf("failed to patch messager %s: patched message of type %s, want %s", name, got, want)
+	}
+	observer := h.observer()
+	for _, n := range sortedNames(loadMap) {
+		msg := patched
+		if n != name {
+			old := current.GetMessagerMap()[n]
+			if old.Message() == nil {
+				continue
+			}
+			*loadMap[n].GetStats() = *old.GetStats()
+			msg = proto.Clone(old.Message())
+		}
+		mctx := observer.OnMessagerStart(ctx, n)
+		err := loadFromMessage(loadMap[n], msg)
+		stats := *loadMap[n].GetStats()
+		observer.OnMessagerEnd(mctx, n, &stats, err)
+		if err != nil {
+			return nil, asLoadError(n, PhaseLoad, err)
+		}
+		h.logMessagerLoaded(n, &stats)
+	}
+	mc := newMessagerContainer(messagerMap)
+	mc.profile = current.profile
+	mc.missingFiles = current.missingFiles
+	if err := h.observePostProcess(ctx, mc, loadMap); err != nil {
+		return nil, err
+	}
+	return &PendingContainer{hub: h, mc: mc}, nil
+}
+
+// loadFromMessage loads msger with msg instead of reading config files.
+func loadFromMessage(msger Messager, msg proto.Message) error {
+	loader, ok := msger.(messageLoader)
+	if !ok {
+		return fmt.Errorf("loading from message: %w", ErrNotSupported)
+	}
+	return loader.loadMessage(msg)
+}
@@ -0,0 +1,103 @@
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/tableauio/tableau/format"
+	"github.com/tableauio/tableau/load"
+)
+
+// Profile is a named overlay of patch directories and per-messager options
+// for an environment, e.g.: "dev", "staging" and "cn-prod". It is registered
+// to the hub by [WithProfile], and loaded by [Hub.LoadProfile].
+type Profile struct {
+	// Base is the name of the profile which this profile is stacked on, so
+	// that the patch directories of this profile are applied after the
+	// base's, and the messager options of this profile override the base's.
+	//
+	// Default: "".
+	Base string
+	// PatchDirs specifies the patch directories in order, the later ones
+	// taking precedence.
+	//
+	// Default: nil.
+	PatchDirs []string
+	// MessagerOptions maps each messager name to its options.
+	//
+	// Default: nil.
+	MessagerOptions map[string]*load.MessagerOptions
+}
+
+// LoadProfile fills messages from files in the specified directory and
+// format, patched by the stack of the named profile and its bases. The
+// active profile is recorded in the loaded container, see
+// [MessagerContainer.GetProfile].
+//
+// The given options take precedence over the profile: PatchDirs replaces the
+// profile's patch directories, and MessagerOptions overrides the profile's
+// options of the same messagers.
+//
+// The active profile is also applied by [Hub.Reload] and [Hub.Watch].
+func (h *Hub) LoadProfile(dir string, format format.Format, profile string, options ...load.Option) error {
+	return h.loadProfile(context.Background(), dir, format, profile, options...)
+}
+
+// loadProfile is like [Hub.LoadProfile], but aborts when ctx is done.
+func (h *Hub) loadProfile(ctx context.Context, dir string, format format.Format, profile string, options ...load.Option) error {
+	options, err := h.profileOptions(profile, options)
+	if err != nil {
+		return err
+	}
+	pending, err := h.PrepareContext(ctx, dir, format, options...)
+	if err != nil {
+		return err
+	}
+	if err := context.Cause(ctx); err != nil {
+		pending.Discard()
+		return err
+	}
+	pending.mc.profile = profile
+	return pending.Commit()
+}
+
+// profileOptions returns options with the named profile applied, in which
+// the given options take precedence. Options are returned as is if profile
+// is empty.
+func (h *Hub) profileOptions(profile string, options []load.Option) ([]load.Option, error) {
+	if profile == "" {
+		return options, nil
+	}
+	resolved, err := h.resolveProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+	messagerOptions := maps.Clone(resolved.MessagerOptions)
+	maps.Copy(messagerOptions, load.ParseOptions(options...).MessagerOptions)
+	options = append([]load.Option{load.PatchDirs(resolved.PatchDirs...)}, options...)
+	return append(options, load.WithMessagerOptions(messagerOptions)), nil
+}
+
+// resolveProfile flattens the stack of the named profile and its bases into
+// one profile.
+func (h *Hub) resolveProfile(name string) (*Profile, error) {
+	var stack []string
+	for next := name; next != ""; {
+		if slices.Contains(stack, next) {
+			return nil, fmt.Errorf("profile cycle: %s -> %s", strings.Join(stack, " -> "), next)
+		}
+		if h.opts.Profiles[next] == nil {
+			return nil, fmt.Errorf("profile %s: %w", next, ErrNotFound)
+		}
+		stack = append(stack, next)
+		next = h.opts.Profiles[next].Base
+	}
+	resolved := &Profile{MessagerOptions: map[string]*load.MessagerOptions{}}
+	for _, profileName := range slices.Backward(stack) {
+		profile := h.opts.Profiles[profileName]
+		resolved.PatchDirs = append(resolved.PatchDirs, profile.PatchDirs...)
+		maps.Copy(resolved.MessagerOptions, profile.MessagerOptions)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,101 @@
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/tableauio/loader/pkg/source"
+	"github.com/tableauio/tableau/format"
+	"github.com/tableauio/tableau/load"
+)
+
+// LoadSource fills messages from config files in src and format, e.g. a
+// config server by [source.NewHTTP]. The Path, PatchPaths and PatchDirs
+// options are all resolved inside src, as [Hub.LoadFS] does.
+//
+// The config files of a messager are its main file and patch files in src,
+// e.g.: "ItemConf.json" and "patchconf/ItemConf.json". If the versions of
+// them and the load options of the messager are all unchanged since the
+// current container was loaded from a source, the messager is skipped and
+// its loaded instance is reused, as [Hub.Reload] does. Messagers with Path,
+// PatchPaths or LoadFunc options are always loaded.
+//
+// NOTE: only output formats (JSON, Bin, Text) are supported.
+func (h *Hub) LoadSource(ctx context.Context, src source.Source, format format.Format, options ...load.Option) error {
+	names, err := src.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list source: %w", err)
+	}
+	ext := configFilename("", format)
+	versions := map[string]string{}
+	for _, name := range names {
+		if !strings.HasSuffix(name, ext) {
+			continue
+		}
+		version, err := src.Version(ctx, name)
+		if err != nil {
+			return fmt.Errorf("failed to get version of %s: %w", name, err)
+		}
+		versions[name] = version
+	}
+	opts := load.ParseOptions(options...)
+	last := h.mc.Load()
+	messagerMap, loadMap := h.reuseMessagers(func(name string) bool {
+		mopts := opts.ParseMessagerOptionsByName(name)
+		if last.sourceVersions == nil || mopts.Path != "" || mopts.PatchPaths != nil || mopts.LoadFunc != nil {
+			return true
+		}
+		if last.sourceOptions[name] != newSourceOptions(format, mopts) {
+			return true
+		}
+		for _, file := range sourcePaths(name, format, mopts) {
+			if versions[file] != last.sourceVersions[file] {
+				return true
+			}
+		}
+		return false
+	})
+	pending, err := h.prepare(ctx, source.FS(ctx, src, names), messagerMap, loadMap, ".", format, options...)
+	if err != nil {
+		return err
+	}
+	pending.mc.sourceVersions = versions
+	pending.mc.sourceOptions = make(map[string]sourceOptions, len(messagerMap))
+	for name := range messagerMap {
+		pending.mc.sourceOptions[name] = newSourceOptions(format, opts.ParseMessagerOptionsByName(name))
+	}
+	return pending.Commit()
+}
+
+// sourceOptions is the comparable form of the load options affecting the
+// content of a messager loaded from a source.
+type sourceOptions struct {
+	format              format.Format
+	locationName        string
+	ignoreUnknownFields bool
+	patchDirs           string
+	mode                load.LoadMode
+	subdirRewrites      string
+}
+
+func newSourceOptions(format format.Format, opts *load.MessagerOptions) sourceOptions {
+	return sourceOptions{
+		format:              format,
+		locationName:        opts.GetLocationName(),
+		ignoreUnknownFields: opts.GetIgnoreUnknownFields(),
+		patchDirs:           fmt.Sprintf("%q", opts.GetPatchDirs()),
+		mode:                opts.GetMode(),
+		subdirRewrites:      fmt.Sprint(opts.GetSubdirRewrites()),
+	}
+}
+
+// sourcePaths returns the paths in a source of the main file and patch
+// files of the named messager.
+func sourcePaths(name string, format format.Format, opts *load.MessagerOptions) []string {
+	filename := configFilename(name, format)
+	paths := []string{filename}
+	for _, dir := range opts.GetPatchDirs() {
+		paths = append(paths, path.Join(dir, filename))
+	}
+	return paths
+}
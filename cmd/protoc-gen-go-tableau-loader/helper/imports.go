@@ -11,5 +11,8 @@ const (
 	TimePackage    = protogen.GoImportPath("time")
 	SortPackage    = protogen.GoImportPath("sort")
 	FmtPackage     = protogen.GoImportPath("fmt")
+	ErrorsPackage  = protogen.GoImportPath("errors")
+	FSPackage      = protogen.GoImportPath("io/fs")
+	ContextPackage = protogen.GoImportPath("context")
 	ProtoPackage   = protogen.GoImportPath("google.golang.org/protobuf/proto")
 )
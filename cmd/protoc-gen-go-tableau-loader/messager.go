@@ -6,6 +6,7 @@ import (
 	"github.com/tableauio/loader/cmd/protoc-gen-go-tableau-loader/helper"
 	"github.com/tableauio/loader/cmd/protoc-gen-go-tableau-loader/indexes"
 	"github.com/tableauio/loader/cmd/protoc-gen-go-tableau-loader/orderedmap"
+	"github.com/tableauio/loader/cmd/protoc-gen-go-tableau-loader/refer"
 	"github.com/tableauio/loader/internal/extensions"
 	"github.com/tableauio/loader/internal/index"
 	"github.com/tableauio/loader/internal/loadutil"
@@ -16,6 +17,10 @@ import (
 	"google.golang.org/protobuf/types/descriptorpb"
 )
 
+// optionalLabel is the worksheet label marking a messager as optional,
+// which tolerates a missing config file, e.g.: labels:{key:"optional" value:"true"}.
+const optionalLabel = "optional"
+
 // generateMessager generates a protoconf file corresponding to the protobuf file.
 // Each wrapped struct type implement the Messager interface.
 func generateMessager(gen *protogen.Plugin, file *protogen.File) {
@@ -35,7 +40,7 @@ func generateFileContent(gen *protogen.Plugin, file *protogen.File, g *protogen.
 		opts := message.Desc.Options().(*descriptorpb.MessageOptions)
 		worksheet := proto.GetExtension(opts, tableaupb.E_Worksheet).(*tableaupb.WorksheetOptions)
 		if worksheet != nil {
-			genMessage(gen, g, message)
+			genMessage(gen, g, message, worksheet)
 
 			messagerName := string(message.Desc.Name())
 			fileMessagers = append(fileMessagers, messagerName)
@@ -56,12 +61,13 @@ func generateRegister(messagers []string, g *protogen.GeneratedFile) {
 }
 
 // genMessage generates a message definition.
-func genMessage(gen *protogen.Plugin, g *protogen.GeneratedFile, message *protogen.Message) {
+func genMessage(gen *protogen.Plugin, g *protogen.GeneratedFile, message *protogen.Message, worksheet *tableaupb.WorksheetOptions) {
 	messagerName := string(message.Desc.Name())
 	indexDescriptor := index.ParseIndexDescriptor(message.Desc)
 
 	orderedMapGenerator := orderedmap.NewGenerator(gen, g, message)
 	indexGenerator := indexes.NewGenerator(gen, g, indexDescriptor, message)
+	referGenerator := refer.NewGenerator(gen, g, message)
 
 	// type definitions
 	orderedMapGenerator.GenOrderedMapTypeDef()
@@ -90,6 +96,14 @@ func genMessage(gen *protogen.Plugin, g *protogen.GeneratedFile, message *protog
 	g.P("}")
 	g.P()
 
+	if worksheet.GetLabels()[optionalLabel] == "true" {
+		g.P("// optional reports that ", messagerName, " tolerates a missing config file.")
+		g.P("func (x *", messagerName, ") optional() bool {")
+		g.P("return true")
+		g.P("}")
+		g.P()
+	}
+
 	g.P("// Data returns the ", messagerName, "'s inner message data.")
 	g.P("func (x *", messagerName, ") Data() *", message.GoIdent, " {")
 	g.P("if x != nil {")
@@ -105,15 +119,73 @@ func genMessage(gen *protogen.Plugin, g *protogen.GeneratedFile, message *protog
 	g.P("defer func ()  {")
 	g.P("x.Stats.Duration = ", helper.TimePackage.Ident("Since"), "(start)")
 	g.P("}()")
+	g.P("x.Stats = Stats{}")
 	g.P("x.data = &", message.GoIdent, "{}")
-	g.P("err := ", helper.LoadPackage.Ident("LoadMessagerInDir"), "(x.data, dir, format, opts)")
+	g.P("err := loadMessagerInDir(x.data, dir, format, opts, &x.Stats)")
 	g.P("if err != nil {")
 	g.P("return err")
 	g.P("}")
+	g.P("return x.afterLoad()")
+	g.P("}")
+	g.P()
+
+	g.P("// loadMessage loads ", messagerName, "'s content from msg instead of files, as Load does")
+	g.P("// after reading files. msg is taken over, and stats of reading files are left as is.")
+	g.P("func (x *", messagerName, ") loadMessage(msg proto.Message) error {")
+	g.P("start := ", helper.TimePackage.Ident("Now"), "()")
+	g.P("defer func ()  {")
+	g.P("x.Stats.Duration = ", helper.TimePackage.Ident("Since"), "(start)")
+	g.P("}()")
+	g.P("x.data = msg.(*", message.GoIdent, ")")
+	g.P("x.Stats.EntryCount = recordCount(x.data)")
+	g.P("return x.afterLoad()")
+	g.P("}")
+	g.P()
+
+	g.P("// afterLoad backs up and processes ", messagerName, "'s loaded content.")
+	g.P("func (x *", messagerName, ") afterLoad() error {")
 	g.P("if x.backup {")
 	g.P("x.originalData = proto.Clone(x.data).(*", message.GoIdent, ")")
 	g.P("}")
-	g.P("return x.processAfterLoad()")
+	g.P("processStart := ", helper.TimePackage.Ident("Now"), "()")
+	g.P("err := x.processAfterLoad()")
+	g.P("x.Stats.ProcessAfterLoadDuration = ", helper.TimePackage.Ident("Since"), "(processStart)")
+	g.P("if err != nil {")
+	g.P("return &LoadError{Messager: x.Name(), Phase: PhaseProcessAfterLoad, Err: err}")
+	g.P("}")
+	g.P("return nil")
+	g.P("}")
+	g.P()
+
+	g.P("// LoadContext loads ", messagerName, "'s content like Load, but aborts when ctx is done.")
+	g.P("// The files are loaded into a new message, which is taken only if loaded")
+	g.P("// successfully, so that ", messagerName, " is left untouched if aborted.")
+	g.P("func (x *", messagerName, ") LoadContext(ctx ", helper.ContextPackage.Ident("Context"), ", dir string, format ", helper.FormatPackage.Ident("Format"), " , opts *", helper.LoadPackage.Ident("MessagerOptions"), ") error {")
+	g.P("start := ", helper.TimePackage.Ident("Now"), "()")
+	g.P("data := &", message.GoIdent, "{}")
+	g.P("stats := Stats{}")
+	g.P("err := loadContext(ctx, x.Name(), opts, func(opts *", helper.LoadPackage.Ident("MessagerOptions"), ") error {")
+	g.P("return loadMessagerInDir(data, dir, format, opts, &stats)")
+	g.P("})")
+	g.P("if err != nil {")
+	g.P("return err")
+	g.P("}")
+	g.P("defer func ()  {")
+	g.P("x.Stats.Duration = ", helper.TimePackage.Ident("Since"), "(start)")
+	g.P("}()")
+	g.P("x.Stats = stats")
+	g.P("x.data = data")
+	g.P("return x.afterLoad()")
+	g.P("}")
+	g.P()
+
+	g.P("// LoadFS loads ", messagerName, "'s content in the given dir of fsys, based on format and messager options.")
+	g.P("func (x *", messagerName, ") LoadFS(fsys ", helper.FSPackage.Ident("FS"), ", dir string, format ", helper.FormatPackage.Ident("Format"), " , opts *", helper.LoadPackage.Ident("MessagerOptions"), ") error {")
+	g.P("mopts, err := fsMessagerOptions(fsys, x.Name(), dir, format, opts)")
+	g.P("if err != nil {")
+	g.P("return err")
+	g.P("}")
+	g.P("return x.Load(dir, format, mopts)")
 	g.P("}")
 	g.P()
 
@@ -155,6 +227,8 @@ func genMessage(gen *protogen.Plugin, g *protogen.GeneratedFile, message *protog
 		g.P()
 	}
 
+	referGenerator.GenReferChecker()
+
 	// syntactic sugar for accessing map items
 	genMapGetters(gen, g, message, 1, nil, messagerName)
 	orderedMapGenerator.GenOrderedMapGetters()
@@ -0,0 +1,279 @@
+package refer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tableauio/loader/cmd/protoc-gen-go-tableau-loader/helper"
+	"github.com/tableauio/loader/internal/options"
+	"github.com/tableauio/loader/internal/refer"
+	"github.com/tableauio/tableau/proto/tableaupb"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// target is a resolved refer: the first-level map of the referred messager,
+// whose key is the referred column.
+type target struct {
+	messager  string // referred messager name, e.g.: "ItemConf"
+	column    string // referred column name, e.g.: "ID"
+	mapGetter string // getter of the first-level map, e.g.: "GetItemMap"
+	keyKind   protoreflect.Kind
+	keyType   string // Go type of the first-level map key, e.g.: "uint32"
+	cast      bool   // whether the field value needs casting to keyType
+	valueKind protoreflect.Kind
+	valueType string // Go type of the field value, e.g.: "int64"
+}
+
+type Generator struct {
+	gen     *protogen.Plugin
+	g       *protogen.GeneratedFile
+	message *protogen.Message
+}
+
+func NewGenerator(gen *protogen.Plugin, g *protogen.GeneratedFile, message *protogen.Message) *Generator {
+	return &Generator{
+		gen:     gen,
+		g:       g,
+		message: message,
+	}
+}
+
+func (x *Generator) NeedGenerate() bool {
+	return x.hasRefer(x.message, map[protoreflect.FullName]bool{})
+}
+
+func (x *Generator) messagerName() string {
+	return string(x.message.Desc.Name())
+}
+
+// hasRefer reports whether the message has any resolvable refer field,
+// including those in nested messages.
+func (x *Generator) hasRefer(message *protogen.Message, visiting map[protoreflect.FullName]bool) bool {
+	if visiting[message.Desc.FullName()] {
+		return false
+	}
+	visiting[message.Desc.FullName()] = true
+	defer delete(visiting, message.Desc.FullName())
+	for _, field := range message.Fields {
+		if x.resolve(field.Desc) != nil {
+			return true
+		}
+		if sub := subMessage(field); sub != nil && x.hasRefer(sub, visiting) {
+			return true
+		}
+	}
+	return false
+}
+
+// subMessage returns the message type of a message field, a message list,
+// or a map with message values. It returns nil for other fields.
+func subMessage(field *protogen.Field) *protogen.Message {
+	if field.Desc.IsMap() {
+		return field.Message.Fields[1].Message
+	}
+	return field.Message
+}
+
+// resolve resolves the refer field prop of fd to the referred messager's
+// first-level map. It returns nil if fd has no refer, or the refer can not
+// be checked by map key lookup.
+func (x *Generator) resolve(fd protoreflect.FieldDescriptor) *target {
+	ref := refer.ParseField(fd)
+	if ref == nil {
+		return nil
+	}
+	valueFd := fd
+	if fd.IsMap() {
+		valueFd = fd.MapValue()
+	}
+	message := x.findMessager(ref.Messager())
+	if message == nil {
+		return nil
+	}
+	for _, field := range message.Fields {
+		if !field.Desc.IsMap() {
+			continue
+		}
+		// only the first-level map is checked
+		opts := field.Desc.Options().(*descriptorpb.FieldOptions)
+		fdOpts := proto.GetExtension(opts, tableaupb.E_Field).(*tableaupb.FieldOptions)
+		keyFd := field.Desc.MapKey()
+		if fdOpts.GetKey() != ref.Column || !compatible(valueFd.Kind(), keyFd.Kind()) {
+			return nil
+		}
+		return &target{
+			messager:  ref.Messager(),
+			column:    ref.Column,
+			mapGetter: "Get" + field.GoName,
+			keyKind:   keyFd.Kind(),
+			keyType:   helper.ParseMapKeyType(keyFd),
+			cast:      valueFd.Kind() != keyFd.Kind(),
+			valueKind: valueFd.Kind(),
+			valueType: helper.ParseMapKeyType(valueFd),
+		}
+	}
+	return nil
+}
+
+// findMessager finds the generated messager by name.
+func (x *Generator) findMessager(name string) *protogen.Message {
+	for _, file := range x.gen.Files {
+		if !options.NeedGenFile(file) {
+			continue
+		}
+		for _, message := range file.Messages {
+			opts := message.Desc.Options().(*descriptorpb.MessageOptions)
+			worksheet := proto.GetExtension(opts, tableaupb.E_Worksheet).(*tableaupb.WorksheetOptions)
+			if worksheet != nil && string(message.Desc.Name()) == name {
+				return message
+			}
+		}
+	}
+	return nil
+}
+
+func isInteger(kind protoreflect.Kind) bool {
+	switch kind {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return true
+	default:
+		return false
+	}
+}
+
+func isSigned(kind protoreflect.Kind) bool {
+	switch kind {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return true
+	default:
+		return false
+	}
+}
+
+// compatible reports whether a field value of kind can be looked up as a
+// map key of keyKind.
+func compatible(kind, keyKind protoreflect.Kind) bool {
+	if isInteger(kind) {
+		return isInteger(keyKind)
+	}
+	return kind == protoreflect.StringKind && keyKind == protoreflect.StringKind
+}
+
+func (x *Generator) GenReferChecker() {
+	if !x.NeedGenerate() {
+		return
+	}
+	x.g.P("// checkRefer checks that values of fields with refer prop exist as keys")
+	x.g.P("// of the referred messagers, and reports dangling references with their")
+	x.g.P("// full key paths. Zero values are treated as empty and skipped.")
+	x.g.P("func (x *", x.messagerName(), ") checkRefer(mc *MessagerContainer) error {")
+	x.g.P("var errs []error")
+	x.genMessage(x.message, "x.Data()", 1, "", nil, map[protoreflect.FullName]bool{})
+	x.g.P("return ", helper.ErrorsPackage.Ident("Join"), "(errs...)")
+	x.g.P("}")
+	x.g.P()
+}
+
+func (x *Generator) genMessage(message *protogen.Message, expr string, depth int, path string, args []string, visiting map[protoreflect.FullName]bool) {
+	visiting[message.Desc.FullName()] = true
+	defer delete(visiting, message.Desc.FullName())
+	for _, field := range message.Fields {
+		fd := field.Desc
+		fieldPath := string(fd.Name())
+		if path != "" {
+			fieldPath = path + "." + fieldPath
+		}
+		getter := expr + ".Get" + field.GoName + "()"
+		if t := x.resolve(fd); t != nil {
+			switch {
+			case fd.IsList():
+				i, v := fmt.Sprintf("i%d", depth), fmt.Sprintf("v%d", depth)
+				x.g.P("for ", i, ", ", v, " := range ", getter, " {")
+				x.genCheck(t, v, fieldPath+"[%d]", append(args, i))
+				x.g.P("}")
+			case fd.IsMap():
+				k, v := fmt.Sprintf("k%d", depth), fmt.Sprintf("v%d", depth)
+				x.genMapRange(fd, getter, k, v)
+				x.genCheck(t, v, fieldPath+mapKeyVerb(fd), append(args, k))
+				x.g.P("}")
+			default:
+				x.genCheck(t, getter, fieldPath, args)
+			}
+			continue
+		}
+		sub := subMessage(field)
+		if sub == nil || visiting[sub.Desc.FullName()] || !x.hasRefer(sub, visiting) {
+			continue
+		}
+		switch {
+		case fd.IsList():
+			i, v := fmt.Sprintf("i%d", depth), fmt.Sprintf("v%d", depth)
+			x.g.P("for ", i, ", ", v, " := range ", getter, " {")
+			x.genMessage(sub, v, depth+1, fieldPath+"[%d]", append(args, i), visiting)
+			x.g.P("}")
+		case fd.IsMap():
+			k, v := fmt.Sprintf("k%d", depth), fmt.Sprintf("v%d", depth)
+			x.genMapRange(fd, getter, k, v)
+			x.genMessage(sub, v, depth+1, fieldPath+mapKeyVerb(fd), append(args, k), visiting)
+			x.g.P("}")
+		default:
+			x.genMessage(sub, getter, depth, fieldPath, args, visiting)
+		}
+	}
+}
+
+// genMapRange opens a loop over the map field in sorted key order, so that
+// dangling references are reported in a stable order.
+func (x *Generator) genMapRange(fd protoreflect.FieldDescriptor, getter, k, v string) {
+	sortFunc := "sortedKeys"
+	if fd.MapKey().Kind() == protoreflect.BoolKind {
+		sortFunc = "sortedBoolKeys"
+	}
+	x.g.P("for _, ", k, " := range ", sortFunc, "(", getter, ") {")
+	x.g.P(v, " := ", getter, "[", k, "]")
+}
+
+// mapKeyVerb returns the path element format of keys of the map field, in
+// which string keys are quoted as pkg/udiff does.
+func mapKeyVerb(fd protoreflect.FieldDescriptor) string {
+	if fd.MapKey().Kind() == protoreflect.StringKind {
+		return "[%q]"
+	}
+	return "[%v]"
+}
+
+func (x *Generator) genCheck(t *target, expr string, path string, args []string) {
+	zero := "0"
+	if t.keyKind == protoreflect.StringKind {
+		zero = `""`
+	}
+	x.g.P("if ref := ", expr, "; ref != ", zero, " {")
+	x.g.P("if conf := mc.Get", t.messager, "(); conf != nil {")
+	if t.cast {
+		// values out of the key type's range can not exist as keys, so
+		// report them instead of looking up the wrapped keys
+		cond := "!ok || " + t.valueType + "(key) != ref"
+		switch {
+		case isSigned(t.valueKind) && !isSigned(t.keyKind):
+			cond += " || ref < 0"
+		case !isSigned(t.valueKind) && isSigned(t.keyKind):
+			cond += " || key < 0"
+		}
+		x.g.P("key := ", t.keyType, "(ref)")
+		x.g.P("if _, ok := conf.Data().", t.mapGetter, "()[key]; ", cond, " {")
+	} else {
+		x.g.P("if _, ok := conf.Data().", t.mapGetter, "()[ref]; !ok {")
+	}
+	errArgs := strings.Join(append(append([]string{}, args...), "ref", "ErrNotFound"), ", ")
+	x.g.P("errs = append(errs, ", helper.FmtPackage.Ident("Errorf"), `("`, path, ": ", t.messager, ".", t.column, `(%v) %w", `, errArgs, "))")
+	x.g.P("}")
+	x.g.P("}")
+	x.g.P("}")
+}
@@ -0,0 +1,55 @@
+package refer
+
+import (
+	"regexp"
+
+	"github.com/tableauio/tableau/proto/tableaupb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// referRegexp matches "SheetName.ColumnName" or "SheetName(SheetAlias).ColumnName".
+var referRegexp = regexp.MustCompile(`^(\w+)(?:\((\w+)\))?\.(\w+)$`)
+
+// Refer is the parsed refer field prop, which ensures a field's value is in
+// another sheet's column value space.
+type Refer struct {
+	Sheet  string // sheet name, e.g.: "Item"
+	Alias  string // optional sheet alias, e.g.: "ItemConf"
+	Column string // column name, e.g.: "ID"
+}
+
+// Messager returns the referred messager name, which is the sheet alias if
+// specified, otherwise the sheet name.
+func (r *Refer) Messager() string {
+	if r.Alias != "" {
+		return r.Alias
+	}
+	return r.Sheet
+}
+
+// Parse parses the refer field prop, e.g.: "Item.ID" or "Item(ItemConf).ID".
+// It returns nil if the refer is empty or malformed.
+func Parse(refer string) *Refer {
+	matches := referRegexp.FindStringSubmatch(refer)
+	if matches == nil {
+		return nil
+	}
+	return &Refer{
+		Sheet:  matches[1],
+		Alias:  matches[2],
+		Column: matches[3],
+	}
+}
+
+// ParseField parses the refer field prop of the field. It returns nil if
+// not specified or malformed.
+func ParseField(fd protoreflect.FieldDescriptor) *Refer {
+	opts, ok := fd.Options().(*descriptorpb.FieldOptions)
+	if !ok {
+		return nil
+	}
+	fdOpts := proto.GetExtension(opts, tableaupb.E_Field).(*tableaupb.FieldOptions)
+	return Parse(fdOpts.GetProp().GetRefer())
+}
@@ -0,0 +1,36 @@
+package refer
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want *Refer
+	}{
+		{"ItemConf.ID", &Refer{Sheet: "ItemConf", Column: "ID"}},
+		{"Item(ItemConf).ID", &Refer{Sheet: "Item", Alias: "ItemConf", Column: "ID"}},
+		{"", nil},
+		{"ItemConf", nil},
+		{"Item(ItemConf.ID", nil},
+		{"ItemConf.ID.Name", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got := Parse(tt.in)
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefer_Messager(t *testing.T) {
+	if got := Parse("ItemConf.ID").Messager(); got != "ItemConf" {
+		t.Errorf("Messager() = %q, want %q", got, "ItemConf")
+	}
+	if got := Parse("Item(ItemConf).ID").Messager(); got != "ItemConf" {
+		t.Errorf("Messager() = %q, want %q", got, "ItemConf")
+	}
+}
@@ -0,0 +1,102 @@
+// Package fswatch detects changes of files in directories, which is used
+// by the generated hub to hot reload configs.
+package fswatch
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"time"
+)
+
+// ErrUnsupported is returned when the backend is not supported on the
+// current platform.
+var ErrUnsupported = errors.New("fswatch: backend not supported on this platform")
+
+// Backend watches the given directories, and sends a value to the returned
+// channel each time files in them changed. The channel is closed when ctx
+// is done.
+//
+// NOTE: a backend may send multiple values for one burst of writes, so the
+// receiver should debounce them.
+type Backend func(ctx context.Context, dirs []string) (<-chan struct{}, error)
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// snapshot records the modification time and size of all regular files in
+// dirs recursively. Non-existent dirs are ignored.
+func snapshot(dirs []string) map[string]fileState {
+	states := map[string]fileState{}
+	for _, dir := range dirs {
+		_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil || !info.Mode().IsRegular() {
+				return nil
+			}
+			states[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+			return nil
+		})
+	}
+	return states
+}
+
+func equalSnapshot(a, b map[string]fileState) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, state := range a {
+		if other, ok := b[path]; !ok || !other.modTime.Equal(state.modTime) || other.size != state.size {
+			return false
+		}
+	}
+	return true
+}
+
+// Poll returns a backend which polls files' modification time and size
+// every interval. It works on all platforms. A missing dir is watched as
+// an empty one, so files created in it later are detected.
+//
+// Default interval: 1s.
+func Poll(interval time.Duration) Backend {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	return func(ctx context.Context, dirs []string) (<-chan struct{}, error) {
+		events := make(chan struct{}, 1)
+		prev := snapshot(dirs)
+		go func() {
+			defer close(events)
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					curr := snapshot(dirs)
+					if !equalSnapshot(prev, curr) {
+						prev = curr
+						notify(events)
+					}
+				}
+			}
+		}()
+		return events, nil
+	}
+}
+
+// notify sends a value to events without blocking. Pending values are
+// coalesced as one.
+func notify(events chan<- struct{}) {
+	select {
+	case events <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,80 @@
+package fswatch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testBackend(t *testing.T, backend Backend) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ItemConf.json")
+	require.NoError(t, os.WriteFile(path, []byte("{}"), 0o644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := backend(ctx, []string{dir})
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"itemMap":{}}`), 0o644))
+	select {
+	case _, ok := <-events:
+		assert.True(t, ok)
+	case <-time.After(5 * time.Second):
+		t.Fatal("change not detected")
+	}
+
+	cancel()
+	for range events {
+		// drain until closed
+	}
+}
+
+func TestPoll(t *testing.T) {
+	testBackend(t, Poll(10*time.Millisecond))
+}
+
+func TestPoll_NotExist(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "not-exist")
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := Poll(10 * time.Millisecond)(ctx, []string{dir})
+	require.NoError(t, err)
+
+	// files created in the missing dir later are detected
+	require.NoError(t, os.Mkdir(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ItemConf.json"), []byte("{}"), 0o644))
+	select {
+	case _, ok := <-events:
+		assert.True(t, ok)
+	case <-time.After(5 * time.Second):
+		t.Fatal("change not detected")
+	}
+
+	cancel()
+	for range events {
+		// drain until closed
+	}
+}
+
+func TestInotify(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := Inotify()(ctx, nil)
+	cancel()
+	if err == ErrUnsupported {
+		t.Skip(err)
+	}
+	testBackend(t, Inotify())
+}
+
+func TestEqualSnapshot(t *testing.T) {
+	now := time.Now()
+	a := map[string]fileState{"a.json": {modTime: now, size: 1}}
+	assert.True(t, equalSnapshot(a, map[string]fileState{"a.json": {modTime: now, size: 1}}))
+	assert.False(t, equalSnapshot(a, map[string]fileState{"a.json": {modTime: now, size: 2}}))
+	assert.False(t, equalSnapshot(a, map[string]fileState{"b.json": {modTime: now, size: 1}}))
+	assert.False(t, equalSnapshot(a, map[string]fileState{}))
+}
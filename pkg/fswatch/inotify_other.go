@@ -0,0 +1,16 @@
+//go:build !linux
+
+package fswatch
+
+import (
+	"context"
+)
+
+// Inotify returns a backend driven by Linux inotify events, which is more
+// responsive and cheaper than polling. On other platforms, it returns
+// [ErrUnsupported] when started.
+func Inotify() Backend {
+	return func(ctx context.Context, dirs []string) (<-chan struct{}, error) {
+		return nil, ErrUnsupported
+	}
+}
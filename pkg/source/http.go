@@ -0,0 +1,147 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// HTTP is a [Source] backed by a config server over HTTP, which serves:
+//   - GET {baseURL}/: a JSON array of all config file names, e.g.:
+//     ["ItemConf.json", "patchconf/ItemConf.json"].
+//   - GET {baseURL}/{name}: the content of the named config file, with an
+//     ETag header.
+//
+// Fetched files are cached with their ETags and revalidated by
+// If-None-Match, so unchanged files are not transferred again. If the
+// server sends no ETag, the SHA-256 checksum of the content is used as the
+// version instead. The content fetched by [HTTP.Version] is served by the
+// next [HTTP.Open] of the same file without fetching again, so a version
+// check followed by a load costs a single request.
+type HTTP struct {
+	baseURL string
+	client  *http.Client
+
+	mu      sync.Mutex
+	cache   map[string]*httpEntry
+	pending map[string]*httpEntry // fetched by Version, and not opened yet
+}
+
+type httpEntry struct {
+	etag    string // ETag sent by server, empty if none
+	version string
+	content []byte
+}
+
+// NewHTTP creates an HTTP source with the base URL of the config server.
+// If client is nil, [http.DefaultClient] is used.
+func NewHTTP(baseURL string, client *http.Client) *HTTP {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTP{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  client,
+		cache:   map[string]*httpEntry{},
+		pending: map[string]*httpEntry{},
+	}
+}
+
+func (s *HTTP) List(ctx context.Context) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+"/", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list %s: %s", req.URL, resp.Status)
+	}
+	var names []string
+	if err := json.NewDecoder(resp.Body).Decode(&names); err != nil {
+		return nil, fmt.Errorf("failed to decode list of %s: %w", req.URL, err)
+	}
+	return names, nil
+}
+
+func (s *HTTP) Open(ctx context.Context, name string) (io.ReadCloser, error) {
+	s.mu.Lock()
+	entry := s.pending[name]
+	delete(s.pending, name)
+	s.mu.Unlock()
+	if entry == nil {
+		var err error
+		if entry, err = s.fetch(ctx, name); err != nil {
+			return nil, err
+		}
+	}
+	return io.NopCloser(bytes.NewReader(entry.content)), nil
+}
+
+func (s *HTTP) Version(ctx context.Context, name string) (string, error) {
+	entry, err := s.fetch(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	s.pending[name] = entry
+	s.mu.Unlock()
+	return entry.version, nil
+}
+
+// fetch gets the named file, revalidating the cached one by If-None-Match.
+func (s *HTTP) fetch(ctx context.Context, name string) (*httpEntry, error) {
+	u, err := url.JoinPath(s.baseURL, name)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	cached := s.cache[name]
+	s.mu.Unlock()
+	if cached != nil && cached.etag != "" {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		if cached == nil {
+			return nil, fmt.Errorf("failed to get %s: unexpected %s", u, resp.Status)
+		}
+		return cached, nil
+	case http.StatusOK:
+	default:
+		return nil, fmt.Errorf("failed to get %s: %s", u, resp.Status)
+	}
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", u, err)
+	}
+	entry := &httpEntry{etag: resp.Header.Get("ETag"), version: resp.Header.Get("ETag"), content: content}
+	if entry.version == "" {
+		checksum := sha256.Sum256(content)
+		entry.version = "sha256:" + hex.EncodeToString(checksum[:])
+	}
+	s.mu.Lock()
+	s.cache[name] = entry
+	s.mu.Unlock()
+	return entry, nil
+}
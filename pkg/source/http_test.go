@@ -0,0 +1,140 @@
+package source
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testServer struct {
+	mu       sync.Mutex
+	files    map[string]string
+	noETag   bool
+	transfer map[string]int // number of full responses of each file
+	requests map[string]int // number of requests of each file
+}
+
+func (s *testServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	name := strings.TrimPrefix(r.URL.Path, "/")
+	if name == "" {
+		var names []string
+		for name := range s.files {
+			names = append(names, name)
+		}
+		_ = json.NewEncoder(w).Encode(names)
+		return
+	}
+	s.requests[name]++
+	content, ok := s.files[name]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if !s.noETag {
+		checksum := sha256.Sum256([]byte(content))
+		etag := `"` + hex.EncodeToString(checksum[:]) + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+	}
+	s.transfer[name]++
+	_, _ = io.WriteString(w, content)
+}
+
+func newTestServer(t *testing.T, files map[string]string) (*testServer, *httptest.Server) {
+	s := &testServer{files: files, transfer: map[string]int{}, requests: map[string]int{}}
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+	return s, server
+}
+
+func TestHTTP(t *testing.T) {
+	ctx := context.Background()
+	s, server := newTestServer(t, map[string]string{
+		"ItemConf.json":           `{"itemMap":{}}`,
+		"patchconf/ItemConf.json": `{}`,
+	})
+	src := NewHTTP(server.URL+"/", nil)
+
+	names, err := src.List(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"ItemConf.json", "patchconf/ItemConf.json"}, names)
+
+	version, err := src.Version(ctx, "ItemConf.json")
+	require.NoError(t, err)
+	rc, err := src.Open(ctx, "ItemConf.json")
+	require.NoError(t, err)
+	content, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	assert.Equal(t, `{"itemMap":{}}`, string(content))
+	// opened with the content fetched by Version without requesting again
+	assert.Equal(t, 1, s.requests["ItemConf.json"])
+
+	rc, err = src.Open(ctx, "ItemConf.json")
+	require.NoError(t, err)
+	content, err = io.ReadAll(rc)
+	require.NoError(t, err)
+	assert.Equal(t, `{"itemMap":{}}`, string(content))
+	// revalidated by If-None-Match without transferring again
+	assert.Equal(t, 2, s.requests["ItemConf.json"])
+	assert.Equal(t, 1, s.transfer["ItemConf.json"])
+
+	s.mu.Lock()
+	s.files["ItemConf.json"] = `{"itemMap":{"1":{}}}`
+	s.mu.Unlock()
+	newVersion, err := src.Version(ctx, "ItemConf.json")
+	require.NoError(t, err)
+	assert.NotEqual(t, version, newVersion)
+	assert.Equal(t, 2, s.transfer["ItemConf.json"])
+
+	_, err = src.Open(ctx, "NotExistConf.json")
+	assert.Error(t, err)
+}
+
+func TestHTTP_NoETag(t *testing.T) {
+	ctx := context.Background()
+	s, server := newTestServer(t, map[string]string{"ItemConf.json": `{}`})
+	s.noETag = true
+	src := NewHTTP(server.URL, nil)
+
+	version, err := src.Version(ctx, "ItemConf.json")
+	require.NoError(t, err)
+	sameVersion, err := src.Version(ctx, "ItemConf.json")
+	require.NoError(t, err)
+	assert.Equal(t, version, sameVersion)
+	assert.Equal(t, 2, s.transfer["ItemConf.json"])
+}
+
+func TestFS(t *testing.T) {
+	ctx := context.Background()
+	_, server := newTestServer(t, map[string]string{"conf/ItemConf.json": `{}`})
+	fsys := FS(ctx, NewHTTP(server.URL, nil), []string{"conf/ItemConf.json"})
+
+	content, err := fs.ReadFile(fsys, "conf/ItemConf.json")
+	require.NoError(t, err)
+	assert.Equal(t, `{}`, string(content))
+
+	info, err := fs.Stat(fsys, "conf/ItemConf.json")
+	require.NoError(t, err)
+	assert.Equal(t, "ItemConf.json", info.Name())
+
+	_, err = fs.Stat(fsys, "conf/HeroConf.json")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	_, err = fs.ReadFile(fsys, "./conf/ItemConf.json")
+	assert.ErrorIs(t, err, fs.ErrInvalid)
+}
@@ -0,0 +1,97 @@
+// Package source provides config sources other than the local disk, e.g.
+// a config server, from which the generated hub loads configs.
+package source
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/fs"
+	"path"
+	"time"
+)
+
+// Source provides config files by slash-separated names relative to its
+// root, e.g.: "ItemConf.json" or "patchconf/ItemConf.json".
+type Source interface {
+	// List returns the names of all config files in the source.
+	List(ctx context.Context) ([]string, error)
+	// Open opens the named config file for reading.
+	Open(ctx context.Context, name string) (io.ReadCloser, error)
+	// Version returns the version of the named config file, e.g. an ETag.
+	// The same version means the same content.
+	Version(ctx context.Context, name string) (string, error)
+}
+
+// FS returns a read-only [fs.FS] view of src, which contains the files
+// with the given names, as listed by [Source.List]. Files are read by
+// [Source.Open] with ctx.
+func FS(ctx context.Context, src Source, names []string) fs.FS {
+	fsys := &sourceFS{ctx: ctx, src: src, names: map[string]bool{}}
+	for _, name := range names {
+		fsys.names[name] = true
+	}
+	return fsys
+}
+
+type sourceFS struct {
+	ctx   context.Context
+	src   Source
+	names map[string]bool
+}
+
+func (f *sourceFS) Open(name string) (fs.File, error) {
+	content, err := f.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return &file{Reader: bytes.NewReader(content), info: fileInfo{name: path.Base(name), size: int64(len(content))}}, nil
+}
+
+func (f *sourceFS) ReadFile(name string) ([]byte, error) {
+	if _, err := f.Stat(name); err != nil {
+		return nil, err
+	}
+	rc, err := f.src.Open(f.ctx, name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	defer rc.Close()
+	content, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: err}
+	}
+	return content, nil
+}
+
+// Stat reports existence of the named file by the listed names, without
+// reading it from the source.
+func (f *sourceFS) Stat(name string) (fs.FileInfo, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrInvalid}
+	}
+	if !f.names[name] {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return fileInfo{name: path.Base(name), size: -1}, nil
+}
+
+type file struct {
+	*bytes.Reader
+	info fileInfo
+}
+
+func (f *file) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *file) Close() error               { return nil }
+
+type fileInfo struct {
+	name string // base name
+	size int64  // -1 if unknown
+}
+
+func (i fileInfo) Name() string       { return i.name }
+func (i fileInfo) Size() int64        { return i.size }
+func (i fileInfo) Mode() fs.FileMode  { return 0o444 }
+func (i fileInfo) ModTime() time.Time { return time.Time{} }
+func (i fileInfo) IsDir() bool        { return false }
+func (i fileInfo) Sys() any           { return nil }
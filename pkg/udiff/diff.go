@@ -0,0 +1,251 @@
+package udiff
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Kind is the kind of a change.
+type Kind int
+
+const (
+	Added    Kind = iota + 1 // the value is added
+	Removed                  // the value is removed
+	Modified                 // the value is modified
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Modified:
+		return "modified"
+	default:
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+}
+
+// StepKind is the kind of a path step.
+type StepKind int
+
+const (
+	FieldStep     StepKind = iota + 1 // a field of a message
+	MapKeyStep                        // a key of a map field
+	ListIndexStep                     // an index of a list field
+)
+
+// Step is a step of a [Path].
+type Step struct {
+	Kind StepKind
+	// Field is the field of a FieldStep, or the map or list field which a
+	// MapKeyStep or ListIndexStep steps into.
+	Field protoreflect.FieldDescriptor
+	Key   protoreflect.MapKey // map key of a MapKeyStep
+	Index int                 // list index of a ListIndexStep
+}
+
+// Path is a typed path from the root message to a value, e.g.:
+// "item_map[1].name". String map keys are quoted, e.g.:
+// `fields["name"].string_value`.
+type Path []Step
+
+func (p Path) String() string {
+	var sb strings.Builder
+	for i, step := range p {
+		switch step.Kind {
+		case FieldStep:
+			if i > 0 {
+				sb.WriteByte('.')
+			}
+			sb.WriteString(string(step.Field.Name()))
+		case MapKeyStep:
+			if key, ok := step.Key.Interface().(string); ok {
+				fmt.Fprintf(&sb, "[%q]", key)
+			} else {
+				fmt.Fprintf(&sb, "[%v]", step.Key.Interface())
+			}
+		case ListIndexStep:
+			fmt.Fprintf(&sb, "[%d]", step.Index)
+		}
+	}
+	return sb.String()
+}
+
+// ValueField returns the field describing values at the path: the map
+// value field if the path ends with a map key, otherwise the last field.
+// It returns nil for the empty path.
+func (p Path) ValueField() protoreflect.FieldDescriptor {
+	if len(p) == 0 {
+		return nil
+	}
+	last := p[len(p)-1]
+	if last.Kind == MapKeyStep {
+		return last.Field.MapValue()
+	}
+	return last.Field
+}
+
+// Change is a changed value between two messages.
+type Change struct {
+	Path Path
+	Kind Kind
+	Old  protoreflect.Value // old value, invalid if added
+	New  protoreflect.Value // new value, invalid if removed
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s %s: %v -> %v", c.Kind, c.Path, formatValue(c.Old), formatValue(c.New))
+}
+
+func formatValue(v protoreflect.Value) string {
+	if !v.IsValid() {
+		return "<none>"
+	}
+	switch v := v.Interface().(type) {
+	case string:
+		return fmt.Sprintf("%q", v)
+	case protoreflect.Message:
+		return fmt.Sprintf("{%v}", v.Interface())
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Diff compares messages a and b of the same type by walking their fields,
+// and returns the changes from a to b in path order:
+//   - messages are compared field by field in field declaration order;
+//   - maps are compared by key, in sorted key order;
+//   - lists are compared by index, and the extra elements of the longer
+//     list are reported as added or removed;
+//   - scalars with presence, e.g.: optional and oneof fields, are reported
+//     as added or removed if set in only one of a and b;
+//   - scalars without presence absent are compared as default values.
+//
+// Unknown fields are not compared, so Diff may report no changes for
+// messages unequal by [proto.Equal].
+//
+// If a and b are of different types, the whole message is reported as
+// modified at the empty path.
+func Diff(a, b proto.Message) []Change {
+	ma, mb := a.ProtoReflect(), b.ProtoReflect()
+	if ma.Descriptor().FullName() != mb.Descriptor().FullName() {
+		return []Change{{Kind: Modified, Old: protoreflect.ValueOfMessage(ma), New: protoreflect.ValueOfMessage(mb)}}
+	}
+	var d differ
+	d.diffMessage(nil, ma, mb)
+	return d.changes
+}
+
+type differ struct {
+	changes []Change
+}
+
+func (d *differ) add(path Path, kind Kind, a, b protoreflect.Value) {
+	d.changes = append(d.changes, Change{Path: slices.Clone(path), Kind: kind, Old: a, New: b})
+}
+
+func (d *differ) diffMessage(path Path, a, b protoreflect.Message) {
+	fields := a.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		fieldPath := append(path, Step{Kind: FieldStep, Field: fd})
+		switch {
+		case fd.IsMap():
+			d.diffMap(fieldPath, fd, a.Get(fd).Map(), b.Get(fd).Map())
+		case fd.IsList():
+			d.diffList(fieldPath, fd, a.Get(fd).List(), b.Get(fd).List())
+		case fd.HasPresence():
+			var va, vb protoreflect.Value
+			if a.Has(fd) {
+				va = a.Get(fd)
+			}
+			if b.Has(fd) {
+				vb = b.Get(fd)
+			}
+			d.diffValue(fieldPath, fd, va, vb)
+		default:
+			d.diffValue(fieldPath, fd, a.Get(fd), b.Get(fd))
+		}
+	}
+}
+
+func (d *differ) diffMap(path Path, fd protoreflect.FieldDescriptor, a, b protoreflect.Map) {
+	for _, key := range mapKeys(a, b) {
+		keyPath := append(path, Step{Kind: MapKeyStep, Field: fd, Key: key})
+		d.diffValue(keyPath, fd.MapValue(), a.Get(key), b.Get(key))
+	}
+}
+
+func (d *differ) diffList(path Path, fd protoreflect.FieldDescriptor, a, b protoreflect.List) {
+	for i := 0; i < max(a.Len(), b.Len()); i++ {
+		var va, vb protoreflect.Value
+		if i < a.Len() {
+			va = a.Get(i)
+		}
+		if i < b.Len() {
+			vb = b.Get(i)
+		}
+		indexPath := append(path, Step{Kind: ListIndexStep, Field: fd, Index: i})
+		d.diffValue(indexPath, fd, va, vb)
+	}
+}
+
+// diffValue compares values a and b described by fd, either of which is
+// invalid if absent.
+func (d *differ) diffValue(path Path, fd protoreflect.FieldDescriptor, a, b protoreflect.Value) {
+	switch {
+	case !a.IsValid() && !b.IsValid():
+	case !a.IsValid():
+		d.add(path, Added, a, b)
+	case !b.IsValid():
+		d.add(path, Removed, a, b)
+	case fd.Message() != nil:
+		d.diffMessage(path, a.Message(), b.Message())
+	case !a.Equal(b):
+		d.add(path, Modified, a, b)
+	}
+}
+
+// mapKeys returns the union of keys of maps a and b in sorted order.
+func mapKeys(a, b protoreflect.Map) []protoreflect.MapKey {
+	keys := make([]protoreflect.MapKey, 0, max(a.Len(), b.Len()))
+	a.Range(func(key protoreflect.MapKey, _ protoreflect.Value) bool {
+		keys = append(keys, key)
+		return true
+	})
+	b.Range(func(key protoreflect.MapKey, _ protoreflect.Value) bool {
+		if !a.Has(key) {
+			keys = append(keys, key)
+		}
+		return true
+	})
+	slices.SortFunc(keys, compareMapKeys)
+	return keys
+}
+
+func compareMapKeys(a, b protoreflect.MapKey) int {
+	switch a.Interface().(type) {
+	case string:
+		return cmp.Compare(a.String(), b.String())
+	case bool:
+		switch {
+		case a.Bool() == b.Bool():
+			return 0
+		case b.Bool():
+			return -1
+		default:
+			return 1
+		}
+	case int32, int64:
+		return cmp.Compare(a.Int(), b.Int())
+	default:
+		return cmp.Compare(a.Uint(), b.Uint())
+	}
+}
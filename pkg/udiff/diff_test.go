@@ -0,0 +1,121 @@
+package udiff
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestDiff_NoDifference(t *testing.T) {
+	assert.Empty(t, Diff(wrapperspb.String("hello"), wrapperspb.String("hello")))
+}
+
+func TestDiff_Scalar(t *testing.T) {
+	changes := Diff(wrapperspb.String("hello"), wrapperspb.String("world"))
+	require.Len(t, changes, 1)
+	assert.Equal(t, "value", changes[0].Path.String())
+	assert.Equal(t, Modified, changes[0].Kind)
+	assert.Equal(t, "hello", changes[0].Old.String())
+	assert.Equal(t, "world", changes[0].New.String())
+
+	// absent scalars are compared as default values
+	changes = Diff(wrapperspb.Int32(0), wrapperspb.Int32(5))
+	require.Len(t, changes, 1)
+	assert.Equal(t, Modified, changes[0].Kind)
+	assert.Equal(t, int64(0), changes[0].Old.Int())
+}
+
+func TestDiff_Presence(t *testing.T) {
+	// oneof scalars with default values are still set
+	var got []string
+	for _, change := range Diff(structpb.NewNumberValue(0), structpb.NewStringValue("")) {
+		got = append(got, fmt.Sprintf("%s %s", change.Kind, change.Path))
+	}
+	assert.Equal(t, []string{"removed number_value", "added string_value"}, got)
+}
+
+func TestDiff_DifferentTypes(t *testing.T) {
+	changes := Diff(wrapperspb.String("hello"), wrapperspb.Int32(1))
+	require.Len(t, changes, 1)
+	assert.Empty(t, changes[0].Path)
+	assert.Equal(t, Modified, changes[0].Kind)
+}
+
+func TestDiff_ComplexMessage(t *testing.T) {
+	original, err := structpb.NewStruct(map[string]any{
+		"name":    "Alice",
+		"age":     30,
+		"address": map[string]any{"city": "Shanghai", "zip": "200000"},
+		"tags":    []any{"admin", "vip"},
+		"removed": true,
+	})
+	require.NoError(t, err)
+	current, err := structpb.NewStruct(map[string]any{
+		"name":    "Alice",
+		"age":     25,
+		"address": map[string]any{"city": "Beijing", "zip": "200000"},
+		"tags":    []any{"user"},
+		"added":   nil,
+	})
+	require.NoError(t, err)
+
+	var got []string
+	for _, change := range Diff(original, current) {
+		got = append(got, fmt.Sprintf("%s %s", change.Kind, change.Path))
+	}
+	// map keys are in sorted order
+	assert.Equal(t, []string{
+		"added fields[\"added\"]",
+		"modified fields[\"address\"].struct_value.fields[\"city\"].string_value",
+		"modified fields[\"age\"].number_value",
+		"removed fields[\"removed\"]",
+		"modified fields[\"tags\"].list_value.values[0].string_value",
+		"removed fields[\"tags\"].list_value.values[1]",
+	}, got)
+}
+
+func TestDiff_Path(t *testing.T) {
+	original, err := structpb.NewStruct(map[string]any{"tags": []any{"admin"}})
+	require.NoError(t, err)
+	current, err := structpb.NewStruct(map[string]any{"tags": []any{"admin", nil}})
+	require.NoError(t, err)
+
+	changes := Diff(original, current)
+	require.Len(t, changes, 1)
+	change := changes[0]
+	assert.Equal(t, Added, change.Kind)
+	assert.False(t, change.Old.IsValid())
+	assert.Equal(t, `added fields["tags"].list_value.values[1]: <none> -> {null_value:NULL_VALUE}`, change.String())
+
+	path := change.Path
+	require.Len(t, path, 5)
+	assert.Equal(t, FieldStep, path[0].Kind)
+	assert.Equal(t, protoreflect.Name("fields"), path[0].Field.Name())
+	assert.Equal(t, MapKeyStep, path[1].Kind)
+	assert.Equal(t, "tags", path[1].Key.String())
+	assert.Equal(t, ListIndexStep, path[4].Kind)
+	assert.Equal(t, 1, path[4].Index)
+	assert.Equal(t, protoreflect.Name("values"), path.ValueField().Name())
+	assert.Equal(t, protoreflect.Name("value"), path[:2].ValueField().Name())
+}
+
+func TestDiff_LargeMap(t *testing.T) {
+	fields := map[string]any{}
+	for i := 0; i < 10000; i++ {
+		fields[fmt.Sprintf("key%05d", i)] = i
+	}
+	original, err := structpb.NewStruct(fields)
+	require.NoError(t, err)
+	fields["key05000"] = -1
+	current, err := structpb.NewStruct(fields)
+	require.NoError(t, err)
+
+	changes := Diff(original, current)
+	require.Len(t, changes, 1)
+	assert.Equal(t, `fields["key05000"].number_value`, changes[0].Path.String())
+}
@@ -0,0 +1,478 @@
+package udiff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Operation is an operation of RFC 6902 JSON Patch.
+type Operation struct {
+	Op    string          `json:"op"`              // "add", "remove", "replace", "move", "copy" or "test"
+	Path  string          `json:"path"`            // JSON pointer to the target
+	From  string          `json:"from,omitempty"`  // JSON pointer to the source of "move" and "copy"
+	Value json.RawMessage `json:"value,omitempty"` // value of "add", "replace" and "test"
+}
+
+// JSONPatch generates the delta from message a to b of the same type as an
+// RFC 6902 JSON Patch, based on their protojson forms with JSON field names.
+// Objects are compared by key in sorted order, and arrays are compared by
+// index.
+func JSONPatch(a, b proto.Message) ([]byte, error) {
+	docA, docB, err := marshalPair(a, b)
+	if err != nil {
+		return nil, err
+	}
+	ops := []Operation{}
+	if err := diffJSON("", docA, docB, &ops); err != nil {
+		return nil, err
+	}
+	return json.Marshal(ops)
+}
+
+// MergePatch generates the delta from message a to b of the same type as an
+// RFC 7386 JSON Merge Patch, based on their protojson forms with JSON field
+// names. Arrays are replaced as a whole, and so are the whole forms if
+// they are not JSON objects, e.g. of well-known wrapper types.
+//
+// NOTE: as RFC 7386 means removal by null, null values in b, e.g. of
+// google.protobuf.Value, can not be represented.
+func MergePatch(a, b proto.Message) ([]byte, error) {
+	docA, docB, err := marshalPair(a, b)
+	if err != nil {
+		return nil, err
+	}
+	objA, okA := docA.(map[string]any)
+	objB, okB := docB.(map[string]any)
+	if !okA || !okB {
+		return json.Marshal(docB)
+	}
+	return json.Marshal(diffMerge(objA, objB))
+}
+
+// ApplyJSONPatch applies the RFC 6902 JSON Patch to the protojson form of
+// msg, and returns the patched result as a new message of the same type.
+// msg is left untouched.
+func ApplyJSONPatch(msg proto.Message, patch []byte) (proto.Message, error) {
+	var ops []Operation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON patch: %w", err)
+	}
+	doc, err := marshalJSON(msg)
+	if err != nil {
+		return nil, err
+	}
+	for i, op := range ops {
+		if doc, err = applyOperation(doc, op); err != nil {
+			return nil, fmt.Errorf("failed to apply JSON patch operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	return unmarshalJSON(msg, doc)
+}
+
+// ApplyMergePatch applies the RFC 7386 JSON Merge Patch to the protojson
+// form of msg, and returns the patched result as a new message of the same
+// type. msg is left untouched.
+func ApplyMergePatch(msg proto.Message, patch []byte) (proto.Message, error) {
+	mergePatch, err := decodeJSON(patch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse merge patch: %w", err)
+	}
+	doc, err := marshalJSON(msg)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalJSON(msg, applyMerge(doc, mergePatch))
+}
+
+func marshalPair(a, b proto.Message) (any, any, error) {
+	nameA, nameB := a.ProtoReflect().Descriptor().FullName(), b.ProtoReflect().Descriptor().FullName()
+	if nameA != nameB {
+		return nil, nil, fmt.Errorf("messages of different types: %s and %s", nameA, nameB)
+	}
+	docA, err := marshalJSON(a)
+	if err != nil {
+		return nil, nil, err
+	}
+	docB, err := marshalJSON(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	return docA, docB, nil
+}
+
+// marshalJSON marshals msg to a generic JSON value by protojson.
+func marshalJSON(msg proto.Message) (any, error) {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s to JSON: %w", msg.ProtoReflect().Descriptor().FullName(), err)
+	}
+	return decodeJSON(data)
+}
+
+// unmarshalJSON unmarshals the generic JSON value to a new message of the
+// same type as msg by protojson.
+func unmarshalJSON(msg proto.Message, doc any) (proto.Message, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	result := msg.ProtoReflect().New().Interface()
+	if err := protojson.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal patched JSON to %s: %w", msg.ProtoReflect().Descriptor().FullName(), err)
+	}
+	return result, nil
+}
+
+// decodeJSON decodes data to a generic JSON value, with numbers kept as
+// [json.Number] to be exact.
+func decodeJSON(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func diffJSON(path string, a, b any, ops *[]Operation) error {
+	switch va := a.(type) {
+	case map[string]any:
+		if vb, ok := b.(map[string]any); ok {
+			keys := make([]string, 0, len(va)+len(vb))
+			for key := range va {
+				keys = append(keys, key)
+			}
+			for key := range vb {
+				if _, ok := va[key]; !ok {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				childPath := path + "/" + escapePointer(key)
+				childA, okA := va[key]
+				childB, okB := vb[key]
+				switch {
+				case !okA:
+					if err := addOperation(ops, "add", childPath, childB); err != nil {
+						return err
+					}
+				case !okB:
+					*ops = append(*ops, Operation{Op: "remove", Path: childPath})
+				default:
+					if err := diffJSON(childPath, childA, childB, ops); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		}
+	case []any:
+		if vb, ok := b.([]any); ok {
+			n := min(len(va), len(vb))
+			for i := 0; i < n; i++ {
+				if err := diffJSON(path+"/"+strconv.Itoa(i), va[i], vb[i], ops); err != nil {
+					return err
+				}
+			}
+			for i := n; i < len(vb); i++ {
+				if err := addOperation(ops, "add", path+"/"+strconv.Itoa(i), vb[i]); err != nil {
+					return err
+				}
+			}
+			// remove from the last, so that the indexes are not shifted
+			for i := len(va) - 1; i >= n; i-- {
+				*ops = append(*ops, Operation{Op: "remove", Path: path + "/" + strconv.Itoa(i)})
+			}
+			return nil
+		}
+	}
+	if !reflect.DeepEqual(a, b) {
+		return addOperation(ops, "replace", path, b)
+	}
+	return nil
+}
+
+func addOperation(ops *[]Operation, op, path string, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	*ops = append(*ops, Operation{Op: op, Path: path, Value: data})
+	return nil
+}
+
+func diffMerge(a, b map[string]any) map[string]any {
+	patch := map[string]any{}
+	for key, vb := range b {
+		va, ok := a[key]
+		if !ok {
+			patch[key] = vb
+			continue
+		}
+		objA, okA := va.(map[string]any)
+		objB, okB := vb.(map[string]any)
+		if okA && okB {
+			if sub := diffMerge(objA, objB); len(sub) != 0 {
+				patch[key] = sub
+			}
+			continue
+		}
+		if !reflect.DeepEqual(va, vb) {
+			patch[key] = vb
+		}
+	}
+	for key := range a {
+		if _, ok := b[key]; !ok {
+			patch[key] = nil
+		}
+	}
+	return patch
+}
+
+func applyMerge(target, patch any) any {
+	patchObj, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	targetObj, ok := target.(map[string]any)
+	if !ok {
+		targetObj = map[string]any{}
+	}
+	for key, value := range patchObj {
+		if value == nil {
+			delete(targetObj, key)
+		} else {
+			targetObj[key] = applyMerge(targetObj[key], value)
+		}
+	}
+	return targetObj
+}
+
+func applyOperation(doc any, op Operation) (any, error) {
+	tokens, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+	switch op.Op {
+	case "add", "replace", "test":
+		if len(op.Value) == 0 {
+			return nil, fmt.Errorf("missing value")
+		}
+		value, err := decodeJSON(op.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value: %w", err)
+		}
+		switch op.Op {
+		case "add":
+			return addValue(doc, tokens, value)
+		case "replace":
+			return replaceValue(doc, tokens, value)
+		default:
+			current, err := getValue(doc, tokens)
+			if err != nil {
+				return nil, err
+			}
+			if !reflect.DeepEqual(current, value) {
+				return nil, fmt.Errorf("test failed")
+			}
+			return doc, nil
+		}
+	case "remove":
+		_, doc, err := removeValue(doc, tokens)
+		return doc, err
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		var value any
+		if op.Op == "move" {
+			if len(from) < len(tokens) && slices.Equal(from, tokens[:len(from)]) {
+				return nil, fmt.Errorf("can not move to a child of %s", op.From)
+			}
+			if value, doc, err = removeValue(doc, from); err != nil {
+				return nil, err
+			}
+		} else {
+			if value, err = getValue(doc, from); err != nil {
+				return nil, err
+			}
+			// deep copy by re-decoding
+			data, err := json.Marshal(value)
+			if err != nil {
+				return nil, err
+			}
+			if value, err = decodeJSON(data); err != nil {
+				return nil, err
+			}
+		}
+		return addValue(doc, tokens, value)
+	default:
+		return nil, fmt.Errorf("unknown op %q", op.Op)
+	}
+}
+
+// parsePointer parses the RFC 6901 JSON pointer into reference tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func escapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+func getValue(doc any, tokens []string) (any, error) {
+	for _, token := range tokens {
+		switch v := doc.(type) {
+		case map[string]any:
+			child, ok := v[token]
+			if !ok {
+				return nil, fmt.Errorf("member %q not found", token)
+			}
+			doc = child
+		case []any:
+			i, err := arrayIndex(token, len(v)-1)
+			if err != nil {
+				return nil, err
+			}
+			doc = v[i]
+		default:
+			return nil, fmt.Errorf("can not reference %q in a scalar", token)
+		}
+	}
+	return doc, nil
+}
+
+// updateParent calls update with the parent of the value referenced by the
+// non-empty tokens, and the last token, and sets the returned parent back.
+func updateParent(doc any, tokens []string, update func(parent any, token string) (any, error)) (any, error) {
+	if len(tokens) == 1 {
+		return update(doc, tokens[0])
+	}
+	child, err := getValue(doc, tokens[:1])
+	if err != nil {
+		return nil, err
+	}
+	child, err = updateParent(child, tokens[1:], update)
+	if err != nil {
+		return nil, err
+	}
+	switch v := doc.(type) {
+	case map[string]any:
+		v[tokens[0]] = child
+	case []any:
+		i, _ := arrayIndex(tokens[0], len(v)-1)
+		v[i] = child
+	}
+	return doc, nil
+}
+
+func addValue(doc any, tokens []string, value any) (any, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	return updateParent(doc, tokens, func(parent any, token string) (any, error) {
+		switch v := parent.(type) {
+		case map[string]any:
+			v[token] = value
+			return v, nil
+		case []any:
+			i := len(v)
+			if token != "-" {
+				var err error
+				if i, err = arrayIndex(token, len(v)); err != nil {
+					return nil, err
+				}
+			}
+			return slices.Insert(v, i, value), nil
+		default:
+			return nil, fmt.Errorf("can not add %q to a scalar", token)
+		}
+	})
+}
+
+func replaceValue(doc any, tokens []string, value any) (any, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	return updateParent(doc, tokens, func(parent any, token string) (any, error) {
+		switch v := parent.(type) {
+		case map[string]any:
+			if _, ok := v[token]; !ok {
+				return nil, fmt.Errorf("member %q not found", token)
+			}
+			v[token] = value
+			return v, nil
+		case []any:
+			i, err := arrayIndex(token, len(v)-1)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = value
+			return v, nil
+		default:
+			return nil, fmt.Errorf("can not replace %q in a scalar", token)
+		}
+	})
+}
+
+// removeValue removes the value referenced by tokens, and returns it with
+// the updated doc.
+func removeValue(doc any, tokens []string) (any, any, error) {
+	if len(tokens) == 0 {
+		return nil, nil, fmt.Errorf("can not remove the whole document")
+	}
+	var removed any
+	doc, err := updateParent(doc, tokens, func(parent any, token string) (any, error) {
+		switch v := parent.(type) {
+		case map[string]any:
+			value, ok := v[token]
+			if !ok {
+				return nil, fmt.Errorf("member %q not found", token)
+			}
+			removed = value
+			delete(v, token)
+			return v, nil
+		case []any:
+			i, err := arrayIndex(token, len(v)-1)
+			if err != nil {
+				return nil, err
+			}
+			removed = v[i]
+			return slices.Delete(v, i, i+1), nil
+		default:
+			return nil, fmt.Errorf("can not remove %q from a scalar", token)
+		}
+	})
+	return removed, doc, err
+}
+
+// arrayIndex parses the array index token, which must be in [0, max].
+func arrayIndex(token string, max int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i > max || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	return i, nil
+}
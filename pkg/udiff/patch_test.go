@@ -0,0 +1,142 @@
+package udiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func newPatchStructs(t *testing.T) (*structpb.Struct, *structpb.Struct) {
+	original, err := structpb.NewStruct(map[string]any{
+		"name":    "Alice",
+		"age":     30,
+		"address": map[string]any{"city": "Shanghai", "zip": "200000"},
+		"tags":    []any{"admin", "vip", "old"},
+		"a/b~c":   true,
+	})
+	require.NoError(t, err)
+	current, err := structpb.NewStruct(map[string]any{
+		"name":    "Alice",
+		"age":     25,
+		"address": map[string]any{"city": "Beijing", "zip": "200000"},
+		"tags":    []any{"user"},
+		"added":   "new",
+	})
+	require.NoError(t, err)
+	return original, current
+}
+
+func TestJSONPatch(t *testing.T) {
+	original, current := newPatchStructs(t)
+	patch, err := JSONPatch(original, current)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"op":"remove","path":"/a~1b~0c"},
+		{"op":"add","path":"/added","value":"new"},
+		{"op":"replace","path":"/address/city","value":"Beijing"},
+		{"op":"replace","path":"/age","value":25},
+		{"op":"replace","path":"/tags/0","value":"user"},
+		{"op":"remove","path":"/tags/2"},
+		{"op":"remove","path":"/tags/1"}
+	]`, string(patch))
+
+	patched, err := ApplyJSONPatch(original, patch)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(current, patched))
+	// the original message is left untouched
+	assert.Equal(t, "Shanghai", original.Fields["address"].GetStructValue().Fields["city"].GetStringValue())
+
+	patch, err = JSONPatch(original, original)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[]`, string(patch))
+
+	_, err = JSONPatch(wrapperspb.String("hello"), wrapperspb.Int32(1))
+	assert.Error(t, err)
+}
+
+func TestMergePatch(t *testing.T) {
+	original, current := newPatchStructs(t)
+	patch, err := MergePatch(original, current)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"a/b~c": null,
+		"added": "new",
+		"address": {"city": "Beijing"},
+		"age": 25,
+		"tags": ["user"]
+	}`, string(patch))
+
+	patched, err := ApplyMergePatch(original, patch)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(current, patched))
+
+	patch, err = MergePatch(original, original)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(patch))
+
+	// non-object forms are replaced as a whole
+	patch, err = MergePatch(wrapperspb.String("hello"), wrapperspb.String("world"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `"world"`, string(patch))
+	patched, err = ApplyMergePatch(wrapperspb.String("hello"), patch)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(wrapperspb.String("world"), patched))
+}
+
+func TestApplyJSONPatch_Operations(t *testing.T) {
+	original, err := structpb.NewStruct(map[string]any{
+		"a": map[string]any{"x": 1},
+		"b": []any{1, 2},
+	})
+	require.NoError(t, err)
+	patched, err := ApplyJSONPatch(original, []byte(`[
+		{"op":"test","path":"/a/x","value":1},
+		{"op":"add","path":"/b/1","value":3},
+		{"op":"add","path":"/b/-","value":4},
+		{"op":"copy","from":"/a","path":"/c"},
+		{"op":"move","from":"/a/x","path":"/d"},
+		{"op":"replace","path":"/c/x","value":5}
+	]`))
+	require.NoError(t, err)
+	want, err := structpb.NewStruct(map[string]any{
+		"a": map[string]any{},
+		"b": []any{1, 3, 2, 4},
+		"c": map[string]any{"x": 5},
+		"d": 1,
+	})
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(want, patched))
+
+	for _, patch := range []string{
+		`[{"op":"test","path":"/a/x","value":2}]`,
+		`[{"op":"remove","path":"/missing"}]`,
+		`[{"op":"replace","path":"/b/2","value":1}]`,
+		`[{"op":"add","path":"/b/01","value":1}]`,
+		`[{"op":"add","path":"/b"}]`,
+		`[{"op":"move","from":"/a","path":"/a/y"}]`,
+		`[{"op":"unknown","path":"/a"}]`,
+		`[{"op":"add","path":"a","value":1}]`,
+		`[{"op":"remove","path":""}]`,
+		`not json`,
+	} {
+		_, err := ApplyJSONPatch(original, []byte(patch))
+		assert.Error(t, err, patch)
+	}
+
+	// the patched JSON must still conform to the message type
+	_, err = ApplyJSONPatch(wrapperspb.Int32(1), []byte(`[{"op":"replace","path":"","value":"str"}]`))
+	assert.Error(t, err)
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	patched, err := ApplyMergePatch(wrapperspb.String("hello"), []byte(`"world"`))
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(wrapperspb.String("world"), patched))
+
+	_, err = ApplyMergePatch(wrapperspb.String("hello"), []byte(`{`))
+	assert.Error(t, err)
+}
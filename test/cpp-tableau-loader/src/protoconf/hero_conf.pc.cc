@@ -15,8 +15,11 @@ const std::string HeroConf::kProtoName = std::string(protoconf::HeroConf::GetDes
 
 bool HeroConf::Load(const std::filesystem::path& dir, Format fmt, std::shared_ptr<const load::MessagerOptions> options /* = nullptr */) {
   tableau::util::TimeProfiler profiler;
-  bool loaded = LoadMessagerInDir(data_, dir, fmt, options);
+  stats_ = Stats{};
+  bool loaded = LoadMessagerInDir(data_, dir, fmt, options, stats_);
+  tableau::util::TimeProfiler process_profiler;
   bool ok = loaded ? ProcessAfterLoad() : false;
+  stats_.process_after_load_duration = process_profiler.Elapse();
   stats_.duration = profiler.Elapse();
   return ok;
 }
@@ -74,8 +77,11 @@ const std::string HeroBaseConf::kProtoName = std::string(protoconf::HeroBaseConf
 
 bool HeroBaseConf::Load(const std::filesystem::path& dir, Format fmt, std::shared_ptr<const load::MessagerOptions> options /* = nullptr */) {
   tableau::util::TimeProfiler profiler;
-  bool loaded = LoadMessagerInDir(data_, dir, fmt, options);
+  stats_ = Stats{};
+  bool loaded = LoadMessagerInDir(data_, dir, fmt, options, stats_);
+  tableau::util::TimeProfiler process_profiler;
   bool ok = loaded ? ProcessAfterLoad() : false;
+  stats_.process_after_load_duration = process_profiler.Elapse();
   stats_.duration = profiler.Elapse();
   return ok;
 }
@@ -6,6 +6,8 @@
 
 #include "hub.pc.h"
 
+#include <algorithm>
+
 #include "load.pc.h"
 #include "logger.pc.h"
 #include "util.pc.h"
@@ -127,12 +129,69 @@ bool Hub::Postprocess(std::shared_ptr<MessagerMap> msger_map) {
   Hub tmp_hub;
   tmp_hub.SetMessagerMap(msger_map);
 
+  std::vector<std::string> names;
+  if (!SortMessagersByDependencies(*msger_map, names)) {
+    return false;
+  }
+
   // messager-level postprocess
-  for (auto iter : *msger_map) {
-    auto msger = iter.second;
+  for (auto&& name : names) {
+    auto msger = msger_map->at(name);
+    util::TimeProfiler profiler;
     bool ok = msger->ProcessAfterLoadAll(tmp_hub);
+    msger->stats_.process_after_load_all_duration = profiler.Elapse();
     if (!ok) {
-      SetErrMsg("hub call ProcessAfterLoadAll failed, messager: " + iter.first);
+      SetErrMsg("hub call ProcessAfterLoadAll failed, messager: " + name);
+      return false;
+    }
+  }
+  return true;
+}
+
+bool Hub::SortMessagersByDependencies(const MessagerMap& msger_map, std::vector<std::string>& names) {
+  enum class State { kUnvisited, kVisiting, kVisited };
+  std::unordered_map<std::string, State> states;
+  std::vector<std::string> path;
+  std::function<bool(const std::string&)> visit = [&](const std::string& name) -> bool {
+    State state = states[name];
+    if (state == State::kVisited) {
+      return true;
+    }
+    if (state == State::kVisiting) {
+      std::string cycle;
+      for (auto it = std::find(path.begin(), path.end(), name); it != path.end(); ++it) {
+        cycle += *it + " -> ";
+      }
+      SetErrMsg("messager dependency cycle: " + cycle + name);
+      return false;
+    }
+    states[name] = State::kVisiting;
+    path.push_back(name);
+    for (auto&& dep : msger_map.at(name)->Dependencies()) {
+      if (msger_map.find(dep) == msger_map.end()) {
+        SetErrMsg("messager " + name + " depends on " + dep + ", which is not registered or filtered out");
+        return false;
+      }
+      if (!visit(dep)) {
+        return false;
+      }
+    }
+    path.pop_back();
+    states[name] = State::kVisited;
+    names.push_back(name);
+    return true;
+  };
+
+  std::vector<std::string> sorted_names;
+  sorted_names.reserve(msger_map.size());
+  for (auto&& it : msger_map) {
+    sorted_names.push_back(it.first);
+  }
+  std::sort(sorted_names.begin(), sorted_names.end());
+  names.clear();
+  names.reserve(msger_map.size());
+  for (auto&& name : sorted_names) {
+    if (!visit(name)) {
       return false;
     }
   }
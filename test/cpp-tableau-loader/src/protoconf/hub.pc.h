@@ -11,6 +11,7 @@
 #include <mutex>
 #include <string>
 #include <unordered_map>
+#include <vector>
 
 #include "load.pc.h"
 #include "scheduler.pc.h"
@@ -79,6 +80,13 @@ class Hub {
   // GetLastLoadedTime returns the time when hub's msger_container_ was last set.
   std::time_t GetLastLoadedTime() const;
 
+  /***** Dependencies *****/
+  // SortMessagersByDependencies sorts messager names in topological order of
+  // their dependencies, and independent messagers are sorted by name. It
+  // returns false if any dependency is missing or cyclic, and the error
+  // message can be obtained by GetErrMsg().
+  static bool SortMessagersByDependencies(const MessagerMap& msger_map, std::vector<std::string>& names);
+
  private:
   std::shared_ptr<MessagerMap> InternalLoad(const std::filesystem::path& dir, Format fmt = Format::kJSON,
                                             std::shared_ptr<const load::Options> options = nullptr) const;
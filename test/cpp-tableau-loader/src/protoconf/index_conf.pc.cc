@@ -15,8 +15,11 @@ const std::string FruitConf::kProtoName = std::string(protoconf::FruitConf::GetD
 
 bool FruitConf::Load(const std::filesystem::path& dir, Format fmt, std::shared_ptr<const load::MessagerOptions> options /* = nullptr */) {
   tableau::util::TimeProfiler profiler;
-  bool loaded = LoadMessagerInDir(data_, dir, fmt, options);
+  stats_ = Stats{};
+  bool loaded = LoadMessagerInDir(data_, dir, fmt, options, stats_);
+  tableau::util::TimeProfiler process_profiler;
   bool ok = loaded ? ProcessAfterLoad() : false;
+  stats_.process_after_load_duration = process_profiler.Elapse();
   stats_.duration = profiler.Elapse();
   return ok;
 }
@@ -195,8 +198,11 @@ const std::string Fruit6Conf::kProtoName = std::string(protoconf::Fruit6Conf::Ge
 
 bool Fruit6Conf::Load(const std::filesystem::path& dir, Format fmt, std::shared_ptr<const load::MessagerOptions> options /* = nullptr */) {
   tableau::util::TimeProfiler profiler;
-  bool loaded = LoadMessagerInDir(data_, dir, fmt, options);
+  stats_ = Stats{};
+  bool loaded = LoadMessagerInDir(data_, dir, fmt, options, stats_);
+  tableau::util::TimeProfiler process_profiler;
   bool ok = loaded ? ProcessAfterLoad() : false;
+  stats_.process_after_load_duration = process_profiler.Elapse();
   stats_.duration = profiler.Elapse();
   return ok;
 }
@@ -363,8 +369,11 @@ const std::string Fruit2Conf::kProtoName = std::string(protoconf::Fruit2Conf::Ge
 
 bool Fruit2Conf::Load(const std::filesystem::path& dir, Format fmt, std::shared_ptr<const load::MessagerOptions> options /* = nullptr */) {
   tableau::util::TimeProfiler profiler;
-  bool loaded = LoadMessagerInDir(data_, dir, fmt, options);
+  stats_ = Stats{};
+  bool loaded = LoadMessagerInDir(data_, dir, fmt, options, stats_);
+  tableau::util::TimeProfiler process_profiler;
   bool ok = loaded ? ProcessAfterLoad() : false;
+  stats_.process_after_load_duration = process_profiler.Elapse();
   stats_.duration = profiler.Elapse();
   return ok;
 }
@@ -609,8 +618,11 @@ const std::string Fruit3Conf::kProtoName = std::string(protoconf::Fruit3Conf::Ge
 
 bool Fruit3Conf::Load(const std::filesystem::path& dir, Format fmt, std::shared_ptr<const load::MessagerOptions> options /* = nullptr */) {
   tableau::util::TimeProfiler profiler;
-  bool loaded = LoadMessagerInDir(data_, dir, fmt, options);
+  stats_ = Stats{};
+  bool loaded = LoadMessagerInDir(data_, dir, fmt, options, stats_);
+  tableau::util::TimeProfiler process_profiler;
   bool ok = loaded ? ProcessAfterLoad() : false;
+  stats_.process_after_load_duration = process_profiler.Elapse();
   stats_.duration = profiler.Elapse();
   return ok;
 }
@@ -750,8 +762,11 @@ const std::string Fruit4Conf::kProtoName = std::string(protoconf::Fruit4Conf::Ge
 
 bool Fruit4Conf::Load(const std::filesystem::path& dir, Format fmt, std::shared_ptr<const load::MessagerOptions> options /* = nullptr */) {
   tableau::util::TimeProfiler profiler;
-  bool loaded = LoadMessagerInDir(data_, dir, fmt, options);
+  stats_ = Stats{};
+  bool loaded = LoadMessagerInDir(data_, dir, fmt, options, stats_);
+  tableau::util::TimeProfiler process_profiler;
   bool ok = loaded ? ProcessAfterLoad() : false;
+  stats_.process_after_load_duration = process_profiler.Elapse();
   stats_.duration = profiler.Elapse();
   return ok;
 }
@@ -1087,8 +1102,11 @@ const std::string Fruit5Conf::kProtoName = std::string(protoconf::Fruit5Conf::Ge
 
 bool Fruit5Conf::Load(const std::filesystem::path& dir, Format fmt, std::shared_ptr<const load::MessagerOptions> options /* = nullptr */) {
   tableau::util::TimeProfiler profiler;
-  bool loaded = LoadMessagerInDir(data_, dir, fmt, options);
+  stats_ = Stats{};
+  bool loaded = LoadMessagerInDir(data_, dir, fmt, options, stats_);
+  tableau::util::TimeProfiler process_profiler;
   bool ok = loaded ? ProcessAfterLoad() : false;
+  stats_.process_after_load_duration = process_profiler.Elapse();
   stats_.duration = profiler.Elapse();
   return ok;
 }
@@ -76,6 +76,62 @@ bool LoadMessagerInDir(google::protobuf::Message& msg, const std::filesystem::pa
   return options->GetLoadFunc()(msg, path, fmt, options);
 }
 
+// CountEntries returns the number of entries of all first-level map and
+// repeated fields of msg.
+static std::size_t CountEntries(const google::protobuf::Message& msg) {
+  const google::protobuf::Reflection* reflection = msg.GetReflection();
+  std::vector<const google::protobuf::FieldDescriptor*> fields;
+  reflection->ListFields(msg, &fields);
+  std::size_t count = 0;
+  for (auto&& field : fields) {
+    if (field->is_repeated()) {
+      count += reflection->FieldSize(msg, field);
+    }
+  }
+  return count;
+}
+
+bool LoadMessagerInDir(google::protobuf::Message& msg, const std::filesystem::path& dir, Format fmt,
+                       std::shared_ptr<const MessagerOptions> options, Messager::Stats& stats) {
+  auto mopts = options ? std::make_shared<MessagerOptions>(*options) : std::make_shared<MessagerOptions>();
+  std::filesystem::path main_path = mopts->path;
+  if (main_path.empty()) {
+    main_path = dir / (std::string(msg.GetDescriptor()->name()) + util::Format2Ext(fmt));
+  }
+  std::chrono::microseconds load_duration{};
+  auto read_func = mopts->GetReadFunc();
+  mopts->read_func = [&stats, read_func](const std::filesystem::path& filename, std::string& content) {
+    util::TimeProfiler profiler;
+    bool ok = read_func(filename, content);
+    stats.read_duration += profiler.Elapse();
+    stats.byte_size += content.size();
+    return ok;
+  };
+  auto load_func = mopts->GetLoadFunc();
+  mopts->load_func = [&stats, &load_duration, &main_path, load_func](
+                         google::protobuf::Message& message, const std::filesystem::path& path, Format format,
+                         std::shared_ptr<const MessagerOptions> opts) {
+    stats.paths.emplace_back(path);
+    if (path != main_path) {
+      stats.patch_paths.emplace_back(path);
+    }
+    util::TimeProfiler profiler;
+    bool ok = load_func(message, path, format, opts);
+    load_duration += profiler.Elapse();
+    return ok;
+  };
+  util::TimeProfiler profiler;
+  bool ok = LoadMessagerInDir(msg, dir, fmt, mopts);
+  stats.unmarshal_duration = load_duration - stats.read_duration;
+  if (!stats.patch_paths.empty()) {
+    stats.patch_duration = profiler.Elapse() - load_duration;
+  }
+  if (ok) {
+    stats.entry_count = CountEntries(msg);
+  }
+  return ok;
+}
+
 bool LoadMessagerWithPatch(google::protobuf::Message& msg, const std::filesystem::path& path, Format fmt,
                            tableau::Patch patch, std::shared_ptr<const MessagerOptions> options /* = nullptr*/) {
   options = options ? options : std::make_shared<MessagerOptions>();
@@ -15,8 +15,11 @@ const std::string PatchReplaceConf::kProtoName = std::string(protoconf::PatchRep
 
 bool PatchReplaceConf::Load(const std::filesystem::path& dir, Format fmt, std::shared_ptr<const load::MessagerOptions> options /* = nullptr */) {
   tableau::util::TimeProfiler profiler;
-  bool loaded = LoadMessagerInDir(data_, dir, fmt, options);
+  stats_ = Stats{};
+  bool loaded = LoadMessagerInDir(data_, dir, fmt, options, stats_);
+  tableau::util::TimeProfiler process_profiler;
   bool ok = loaded ? ProcessAfterLoad() : false;
+  stats_.process_after_load_duration = process_profiler.Elapse();
   stats_.duration = profiler.Elapse();
   return ok;
 }
@@ -25,8 +28,11 @@ const std::string PatchMergeConf::kProtoName = std::string(protoconf::PatchMerge
 
 bool PatchMergeConf::Load(const std::filesystem::path& dir, Format fmt, std::shared_ptr<const load::MessagerOptions> options /* = nullptr */) {
   tableau::util::TimeProfiler profiler;
-  bool loaded = LoadMessagerInDir(data_, dir, fmt, options);
+  stats_ = Stats{};
+  bool loaded = LoadMessagerInDir(data_, dir, fmt, options, stats_);
+  tableau::util::TimeProfiler process_profiler;
   bool ok = loaded ? ProcessAfterLoad() : false;
+  stats_.process_after_load_duration = process_profiler.Elapse();
   stats_.duration = profiler.Elapse();
   return ok;
 }
@@ -43,8 +49,11 @@ const std::string RecursivePatchConf::kProtoName = std::string(protoconf::Recurs
 
 bool RecursivePatchConf::Load(const std::filesystem::path& dir, Format fmt, std::shared_ptr<const load::MessagerOptions> options /* = nullptr */) {
   tableau::util::TimeProfiler profiler;
-  bool loaded = LoadMessagerInDir(data_, dir, fmt, options);
+  stats_ = Stats{};
+  bool loaded = LoadMessagerInDir(data_, dir, fmt, options, stats_);
+  tableau::util::TimeProfiler process_profiler;
   bool ok = loaded ? ProcessAfterLoad() : false;
+  stats_.process_after_load_duration = process_profiler.Elapse();
   stats_.duration = profiler.Elapse();
   return ok;
 }
@@ -15,8 +15,11 @@ const std::string ActivityConf::kProtoName = std::string(protoconf::ActivityConf
 
 bool ActivityConf::Load(const std::filesystem::path& dir, Format fmt, std::shared_ptr<const load::MessagerOptions> options /* = nullptr */) {
   tableau::util::TimeProfiler profiler;
-  bool loaded = LoadMessagerInDir(data_, dir, fmt, options);
+  stats_ = Stats{};
+  bool loaded = LoadMessagerInDir(data_, dir, fmt, options, stats_);
+  tableau::util::TimeProfiler process_profiler;
   bool ok = loaded ? ProcessAfterLoad() : false;
+  stats_.process_after_load_duration = process_profiler.Elapse();
   stats_.duration = profiler.Elapse();
   return ok;
 }
@@ -401,8 +404,11 @@ const std::string ChapterConf::kProtoName = std::string(protoconf::ChapterConf::
 
 bool ChapterConf::Load(const std::filesystem::path& dir, Format fmt, std::shared_ptr<const load::MessagerOptions> options /* = nullptr */) {
   tableau::util::TimeProfiler profiler;
-  bool loaded = LoadMessagerInDir(data_, dir, fmt, options);
+  stats_ = Stats{};
+  bool loaded = LoadMessagerInDir(data_, dir, fmt, options, stats_);
+  tableau::util::TimeProfiler process_profiler;
   bool ok = loaded ? ProcessAfterLoad() : false;
+  stats_.process_after_load_duration = process_profiler.Elapse();
   stats_.duration = profiler.Elapse();
   return ok;
 }
@@ -419,8 +425,11 @@ const std::string ThemeConf::kProtoName = std::string(protoconf::ThemeConf::GetD
 
 bool ThemeConf::Load(const std::filesystem::path& dir, Format fmt, std::shared_ptr<const load::MessagerOptions> options /* = nullptr */) {
   tableau::util::TimeProfiler profiler;
-  bool loaded = LoadMessagerInDir(data_, dir, fmt, options);
+  stats_ = Stats{};
+  bool loaded = LoadMessagerInDir(data_, dir, fmt, options, stats_);
+  tableau::util::TimeProfiler process_profiler;
   bool ok = loaded ? ProcessAfterLoad() : false;
+  stats_.process_after_load_duration = process_profiler.Elapse();
   stats_.duration = profiler.Elapse();
   return ok;
 }
@@ -449,8 +458,11 @@ const std::string TaskConf::kProtoName = std::string(protoconf::TaskConf::GetDes
 
 bool TaskConf::Load(const std::filesystem::path& dir, Format fmt, std::shared_ptr<const load::MessagerOptions> options /* = nullptr */) {
   tableau::util::TimeProfiler profiler;
-  bool loaded = LoadMessagerInDir(data_, dir, fmt, options);
+  stats_ = Stats{};
+  bool loaded = LoadMessagerInDir(data_, dir, fmt, options, stats_);
+  tableau::util::TimeProfiler process_profiler;
   bool ok = loaded ? ProcessAfterLoad() : false;
+  stats_.process_after_load_duration = process_profiler.Elapse();
   stats_.duration = profiler.Elapse();
   return ok;
 }
@@ -628,8 +640,11 @@ const std::string StrcaseConf::kProtoName = std::string(protoconf::StrcaseConf::
 
 bool StrcaseConf::Load(const std::filesystem::path& dir, Format fmt, std::shared_ptr<const load::MessagerOptions> options /* = nullptr */) {
   tableau::util::TimeProfiler profiler;
-  bool loaded = LoadMessagerInDir(data_, dir, fmt, options);
+  stats_ = Stats{};
+  bool loaded = LoadMessagerInDir(data_, dir, fmt, options, stats_);
+  tableau::util::TimeProfiler process_profiler;
   bool ok = loaded ? ProcessAfterLoad() : false;
+  stats_.process_after_load_duration = process_profiler.Elapse();
   stats_.duration = profiler.Elapse();
   return ok;
 }
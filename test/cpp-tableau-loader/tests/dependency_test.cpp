@@ -0,0 +1,64 @@
+// Messager dependency sorting tests for the C++ loader, mirroring:
+//   - Go:  test/go-tableau-loader/dependency_test.go
+//   - C#:  test/csharp-tableau-loader/tests/DependencyTests.cs
+
+#include <gtest/gtest.h>
+
+#include "protoconf/hub.pc.h"
+
+namespace {
+
+// DependencyTestConf is a messager with the given dependencies only.
+class DependencyTestConf : public tableau::Messager {
+ public:
+  explicit DependencyTestConf(std::vector<std::string> deps) : deps_(std::move(deps)) {}
+  virtual bool Load(const std::filesystem::path&, tableau::Format,
+                    std::shared_ptr<const tableau::load::MessagerOptions> options = nullptr) override {
+    return true;
+  }
+  virtual std::vector<std::string> Dependencies() const override { return deps_; }
+
+ private:
+  std::vector<std::string> deps_;
+};
+
+tableau::MessagerMap NewMessagerMap(
+    const std::vector<std::pair<std::string, std::vector<std::string>>>& messagers) {
+  tableau::MessagerMap msger_map;
+  for (auto&& [name, deps] : messagers) {
+    msger_map[name] = std::make_shared<DependencyTestConf>(deps);
+  }
+  return msger_map;
+}
+
+TEST(DependencyTest, SortMessagersByDependencies_Order) {
+  // AConf is placed before BConf in name order, but it depends on BConf,
+  // so it must be processed after.
+  auto msger_map = NewMessagerMap({{"AConf", {"BConf"}}, {"BConf", {"CConf"}}, {"CConf", {}}, {"DConf", {}}});
+  std::vector<std::string> names;
+  ASSERT_TRUE(tableau::Hub::SortMessagersByDependencies(msger_map, names)) << tableau::GetErrMsg();
+  EXPECT_EQ(names, (std::vector<std::string>{"CConf", "BConf", "AConf", "DConf"}));
+}
+
+TEST(DependencyTest, SortMessagersByDependencies_Missing) {
+  auto msger_map = NewMessagerMap({{"AConf", {"BConf"}}});
+  std::vector<std::string> names;
+  ASSERT_FALSE(tableau::Hub::SortMessagersByDependencies(msger_map, names));
+  EXPECT_NE(tableau::GetErrMsg().find("AConf depends on BConf"), std::string::npos) << tableau::GetErrMsg();
+}
+
+TEST(DependencyTest, SortMessagersByDependencies_Cycle) {
+  auto msger_map = NewMessagerMap({{"AConf", {"BConf"}}, {"BConf", {"CConf"}}, {"CConf", {"BConf"}}});
+  std::vector<std::string> names;
+  ASSERT_FALSE(tableau::Hub::SortMessagersByDependencies(msger_map, names));
+  EXPECT_NE(tableau::GetErrMsg().find("BConf -> CConf -> BConf"), std::string::npos) << tableau::GetErrMsg();
+}
+
+TEST(DependencyTest, SortMessagersByDependencies_SelfCycle) {
+  auto msger_map = NewMessagerMap({{"AConf", {"AConf"}}});
+  std::vector<std::string> names;
+  ASSERT_FALSE(tableau::Hub::SortMessagersByDependencies(msger_map, names));
+  EXPECT_NE(tableau::GetErrMsg().find("AConf -> AConf"), std::string::npos) << tableau::GetErrMsg();
+}
+
+}  // namespace
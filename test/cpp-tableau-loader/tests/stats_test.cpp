@@ -0,0 +1,48 @@
+// Loading stats tests for the C++ loader, mirroring:
+//   - Go:  test/go-tableau-loader/main_test.go::Test_Stats
+//   - C#:  test/csharp-tableau-loader/tests/StatsTests.cs
+
+#include <gtest/gtest.h>
+
+#include "protoconf/item_conf.pc.h"
+#include "protoconf/patch_conf.pc.h"
+#include "tests/test_paths.h"
+
+namespace {
+
+std::shared_ptr<tableau::load::MessagerOptions> NewOptions() {
+  auto options = std::make_shared<tableau::load::MessagerOptions>();
+  options->ignore_unknown_fields = true;
+  return options;
+}
+
+TEST(StatsTest, LoadMessagerInDir_FillsStatsBreakdown) {
+  auto options = NewOptions();
+  options->patch_dirs = {test::TestPaths::PatchConf()};
+  protoconf::PatchMergeConf msg;
+  tableau::Messager::Stats stats;
+  bool ok = tableau::load::LoadMessagerInDir(msg, test::TestPaths::Conf(), tableau::Format::kJSON, options, stats);
+  ASSERT_TRUE(ok) << "load failed: " << tableau::GetErrMsg();
+
+  auto main_path = test::TestPaths::Conf() / "PatchMergeConf.json";
+  auto patch_path = test::TestPaths::PatchConf() / "PatchMergeConf.json";
+  EXPECT_EQ(stats.paths, (std::vector<std::filesystem::path>{main_path, patch_path}));
+  EXPECT_EQ(stats.patch_paths, (std::vector<std::filesystem::path>{patch_path}));
+  EXPECT_EQ(stats.byte_size, std::filesystem::file_size(main_path) + std::filesystem::file_size(patch_path));
+  EXPECT_EQ(stats.entry_count, static_cast<std::size_t>(msg.price_list_size() + msg.replace_price_list_size() +
+                                                        msg.item_map_size() + msg.replace_item_map_size()));
+}
+
+TEST(StatsTest, LoadMessagerInDir_NoPatch) {
+  protoconf::ItemConf msg;
+  tableau::Messager::Stats stats;
+  bool ok = tableau::load::LoadMessagerInDir(msg, test::TestPaths::Conf(), tableau::Format::kJSON, NewOptions(), stats);
+  ASSERT_TRUE(ok) << "load failed: " << tableau::GetErrMsg();
+
+  EXPECT_EQ(stats.paths, (std::vector<std::filesystem::path>{test::TestPaths::Conf() / "ItemConf.json"}));
+  EXPECT_TRUE(stats.patch_paths.empty());
+  EXPECT_EQ(stats.patch_duration.count(), 0);
+  EXPECT_EQ(stats.entry_count, static_cast<std::size_t>(msg.item_map_size()));
+}
+
+}  // namespace
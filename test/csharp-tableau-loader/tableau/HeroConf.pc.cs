@@ -41,10 +41,11 @@ namespace Tableau
         public override bool Load(string dir, Format fmt, in Load.MessagerOptions? options = null)
         {
             var start = DateTime.Now;
+            LoadStats = new Stats();
             try
             {
                 _data = (Protoconf.HeroConf)(
-                    Tableau.Load.LoadMessagerInDir(Protoconf.HeroConf.Descriptor, dir, fmt, options)
+                    Tableau.Load.LoadMessagerInDir(Protoconf.HeroConf.Descriptor, dir, fmt, options, LoadStats)
                     ?? throw new InvalidOperationException()
                 );
             }
@@ -56,8 +57,11 @@ namespace Tableau
                 }
                 return false;
             }
+            var processStart = DateTime.Now;
+            bool ok = ProcessAfterLoad();
+            LoadStats.ProcessAfterLoadDuration = DateTime.Now - processStart;
             LoadStats.Duration = DateTime.Now - start;
-            return ProcessAfterLoad();
+            return ok;
         }
 
         /// <summary>
@@ -135,10 +139,11 @@ namespace Tableau
         public override bool Load(string dir, Format fmt, in Load.MessagerOptions? options = null)
         {
             var start = DateTime.Now;
+            LoadStats = new Stats();
             try
             {
                 _data = (Protoconf.HeroBaseConf)(
-                    Tableau.Load.LoadMessagerInDir(Protoconf.HeroBaseConf.Descriptor, dir, fmt, options)
+                    Tableau.Load.LoadMessagerInDir(Protoconf.HeroBaseConf.Descriptor, dir, fmt, options, LoadStats)
                     ?? throw new InvalidOperationException()
                 );
             }
@@ -150,8 +155,11 @@ namespace Tableau
                 }
                 return false;
             }
+            var processStart = DateTime.Now;
+            bool ok = ProcessAfterLoad();
+            LoadStats.ProcessAfterLoadDuration = DateTime.Now - processStart;
             LoadStats.Duration = DateTime.Now - start;
-            return ProcessAfterLoad();
+            return ok;
         }
 
         /// <summary>
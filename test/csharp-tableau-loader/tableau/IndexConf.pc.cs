@@ -46,10 +46,11 @@ namespace Tableau
         public override bool Load(string dir, Format fmt, in Load.MessagerOptions? options = null)
         {
             var start = DateTime.Now;
+            LoadStats = new Stats();
             try
             {
                 _data = (Protoconf.FruitConf)(
-                    Tableau.Load.LoadMessagerInDir(Protoconf.FruitConf.Descriptor, dir, fmt, options)
+                    Tableau.Load.LoadMessagerInDir(Protoconf.FruitConf.Descriptor, dir, fmt, options, LoadStats)
                     ?? throw new InvalidOperationException()
                 );
             }
@@ -61,8 +62,11 @@ namespace Tableau
                 }
                 return false;
             }
+            var processStart = DateTime.Now;
+            bool ok = ProcessAfterLoad();
+            LoadStats.ProcessAfterLoadDuration = DateTime.Now - processStart;
             LoadStats.Duration = DateTime.Now - start;
-            return ProcessAfterLoad();
+            return ok;
         }
 
         /// <summary>
@@ -298,10 +302,11 @@ namespace Tableau
         public override bool Load(string dir, Format fmt, in Load.MessagerOptions? options = null)
         {
             var start = DateTime.Now;
+            LoadStats = new Stats();
             try
             {
                 _data = (Protoconf.Fruit6Conf)(
-                    Tableau.Load.LoadMessagerInDir(Protoconf.Fruit6Conf.Descriptor, dir, fmt, options)
+                    Tableau.Load.LoadMessagerInDir(Protoconf.Fruit6Conf.Descriptor, dir, fmt, options, LoadStats)
                     ?? throw new InvalidOperationException()
                 );
             }
@@ -313,8 +318,11 @@ namespace Tableau
                 }
                 return false;
             }
+            var processStart = DateTime.Now;
+            bool ok = ProcessAfterLoad();
+            LoadStats.ProcessAfterLoadDuration = DateTime.Now - processStart;
             LoadStats.Duration = DateTime.Now - start;
-            return ProcessAfterLoad();
+            return ok;
         }
 
         /// <summary>
@@ -572,10 +580,11 @@ namespace Tableau
         public override bool Load(string dir, Format fmt, in Load.MessagerOptions? options = null)
         {
             var start = DateTime.Now;
+            LoadStats = new Stats();
             try
             {
                 _data = (Protoconf.Fruit2Conf)(
-                    Tableau.Load.LoadMessagerInDir(Protoconf.Fruit2Conf.Descriptor, dir, fmt, options)
+                    Tableau.Load.LoadMessagerInDir(Protoconf.Fruit2Conf.Descriptor, dir, fmt, options, LoadStats)
                     ?? throw new InvalidOperationException()
                 );
             }
@@ -587,8 +596,11 @@ namespace Tableau
                 }
                 return false;
             }
+            var processStart = DateTime.Now;
+            bool ok = ProcessAfterLoad();
+            LoadStats.ProcessAfterLoadDuration = DateTime.Now - processStart;
             LoadStats.Duration = DateTime.Now - start;
-            return ProcessAfterLoad();
+            return ok;
         }
 
         /// <summary>
@@ -908,10 +920,11 @@ namespace Tableau
         public override bool Load(string dir, Format fmt, in Load.MessagerOptions? options = null)
         {
             var start = DateTime.Now;
+            LoadStats = new Stats();
             try
             {
                 _data = (Protoconf.Fruit3Conf)(
-                    Tableau.Load.LoadMessagerInDir(Protoconf.Fruit3Conf.Descriptor, dir, fmt, options)
+                    Tableau.Load.LoadMessagerInDir(Protoconf.Fruit3Conf.Descriptor, dir, fmt, options, LoadStats)
                     ?? throw new InvalidOperationException()
                 );
             }
@@ -923,8 +936,11 @@ namespace Tableau
                 }
                 return false;
             }
+            var processStart = DateTime.Now;
+            bool ok = ProcessAfterLoad();
+            LoadStats.ProcessAfterLoadDuration = DateTime.Now - processStart;
             LoadStats.Duration = DateTime.Now - start;
-            return ProcessAfterLoad();
+            return ok;
         }
 
         /// <summary>
@@ -1187,10 +1203,11 @@ namespace Tableau
         public override bool Load(string dir, Format fmt, in Load.MessagerOptions? options = null)
         {
             var start = DateTime.Now;
+            LoadStats = new Stats();
             try
             {
                 _data = (Protoconf.Fruit4Conf)(
-                    Tableau.Load.LoadMessagerInDir(Protoconf.Fruit4Conf.Descriptor, dir, fmt, options)
+                    Tableau.Load.LoadMessagerInDir(Protoconf.Fruit4Conf.Descriptor, dir, fmt, options, LoadStats)
                     ?? throw new InvalidOperationException()
                 );
             }
@@ -1202,8 +1219,11 @@ namespace Tableau
                 }
                 return false;
             }
+            var processStart = DateTime.Now;
+            bool ok = ProcessAfterLoad();
+            LoadStats.ProcessAfterLoadDuration = DateTime.Now - processStart;
             LoadStats.Duration = DateTime.Now - start;
-            return ProcessAfterLoad();
+            return ok;
         }
 
         /// <summary>
@@ -1597,10 +1617,11 @@ namespace Tableau
         public override bool Load(string dir, Format fmt, in Load.MessagerOptions? options = null)
         {
             var start = DateTime.Now;
+            LoadStats = new Stats();
             try
             {
                 _data = (Protoconf.Fruit5Conf)(
-                    Tableau.Load.LoadMessagerInDir(Protoconf.Fruit5Conf.Descriptor, dir, fmt, options)
+                    Tableau.Load.LoadMessagerInDir(Protoconf.Fruit5Conf.Descriptor, dir, fmt, options, LoadStats)
                     ?? throw new InvalidOperationException()
                 );
             }
@@ -1612,8 +1633,11 @@ namespace Tableau
                 }
                 return false;
             }
+            var processStart = DateTime.Now;
+            bool ok = ProcessAfterLoad();
+            LoadStats.ProcessAfterLoadDuration = DateTime.Now - processStart;
             LoadStats.Duration = DateTime.Now - start;
-            return ProcessAfterLoad();
+            return ok;
         }
 
         /// <summary>
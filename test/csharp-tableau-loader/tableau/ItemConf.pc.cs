@@ -152,10 +152,11 @@ namespace Tableau
         public override bool Load(string dir, Format fmt, in Load.MessagerOptions? options = null)
         {
             var start = DateTime.Now;
+            LoadStats = new Stats();
             try
             {
                 _data = (Protoconf.ItemConf)(
-                    Tableau.Load.LoadMessagerInDir(Protoconf.ItemConf.Descriptor, dir, fmt, options)
+                    Tableau.Load.LoadMessagerInDir(Protoconf.ItemConf.Descriptor, dir, fmt, options, LoadStats)
                     ?? throw new InvalidOperationException()
                 );
             }
@@ -167,8 +168,11 @@ namespace Tableau
                 }
                 return false;
             }
+            var processStart = DateTime.Now;
+            bool ok = ProcessAfterLoad();
+            LoadStats.ProcessAfterLoadDuration = DateTime.Now - processStart;
             LoadStats.Duration = DateTime.Now - start;
-            return ProcessAfterLoad();
+            return ok;
         }
 
         /// <summary>
@@ -7,6 +7,7 @@
 #nullable enable
 using System;
 using System.Collections.Generic;
+using System.Diagnostics;
 using System.IO;
 using pb = global::Google.Protobuf;
 using pbr = global::Google.Protobuf.Reflection;
@@ -175,6 +176,85 @@ namespace Tableau
             return loadFunc(desc, path, fmt, options);
         }
 
+        /// <summary>
+        /// LoadMessagerInDir loads a protobuf message from the specified directory and format,
+        /// and fills the read, unmarshal and patch timings, file paths, byte size and entry
+        /// count into stats.
+        /// </summary>
+        public static pb::IMessage? LoadMessagerInDir(pbr::MessageDescriptor desc, string dir, Format fmt, in MessagerOptions? options, Messager.Stats stats)
+        {
+            var mopts = (MessagerOptions?)options?.Clone() ?? new MessagerOptions();
+            string mainPath = string.IsNullOrEmpty(mopts.Path) ? Path.Combine(dir, desc.Name + Util.Format2Ext(fmt)) : mopts.Path;
+            var loadDuration = TimeSpan.Zero;
+            var readFunc = mopts.ReadFunc ?? File.ReadAllBytes;
+            mopts.ReadFunc = (string path) =>
+            {
+                var sw = Stopwatch.StartNew();
+                try
+                {
+                    var content = readFunc(path);
+                    stats.ByteSize += content.Length;
+                    return content;
+                }
+                finally
+                {
+                    stats.ReadDuration += sw.Elapsed;
+                }
+            };
+            var loadFunc = mopts.LoadFunc ?? LoadMessager;
+            mopts.LoadFunc = (pbr::MessageDescriptor d, string path, Format f, in MessagerOptions? o) =>
+            {
+                stats.Paths.Add(path);
+                if (path != mainPath)
+                {
+                    stats.PatchPaths.Add(path);
+                }
+                var sw = Stopwatch.StartNew();
+                try
+                {
+                    return loadFunc(d, path, f, o);
+                }
+                finally
+                {
+                    loadDuration += sw.Elapsed;
+                }
+            };
+            var total = Stopwatch.StartNew();
+            try
+            {
+                var msg = LoadMessagerInDir(desc, dir, fmt, mopts);
+                if (msg != null)
+                {
+                    stats.EntryCount = CountEntries(msg);
+                }
+                return msg;
+            }
+            finally
+            {
+                stats.UnmarshalDuration = loadDuration - stats.ReadDuration;
+                if (stats.PatchPaths.Count > 0)
+                {
+                    stats.PatchDuration = total.Elapsed - loadDuration;
+                }
+            }
+        }
+
+        /// <summary>
+        /// CountEntries returns the number of entries of all first-level map and repeated fields.
+        /// </summary>
+        private static int CountEntries(pb::IMessage msg)
+        {
+            int count = 0;
+            foreach (var field in msg.Descriptor.Fields.InFieldNumberOrder())
+            {
+                if (field.IsRepeated && field.Accessor.GetValue(msg) is System.Collections.ICollection collection)
+                {
+                    count += collection.Count;
+                }
+            }
+            return count;
+        }
+
         /// <summary>
         /// LoadMessagerWithPatch loads a protobuf message with patch support.
         /// </summary>
@@ -340,6 +420,24 @@ namespace Tableau
         {
             /// <summary>Total load time consuming.</summary>
             public TimeSpan Duration;
+            /// <summary>Time consuming of reading files.</summary>
+            public TimeSpan ReadDuration;
+            /// <summary>Time consuming of unmarshaling files.</summary>
+            public TimeSpan UnmarshalDuration;
+            /// <summary>Time consuming of merging patches.</summary>
+            public TimeSpan PatchDuration;
+            /// <summary>Time consuming of ProcessAfterLoad, e.g. building ordered maps and indexes.</summary>
+            public TimeSpan ProcessAfterLoadDuration;
+            /// <summary>Time consuming of ProcessAfterLoadAll, not included in Duration.</summary>
+            public TimeSpan ProcessAfterLoadAllDuration;
+            /// <summary>All loaded file paths, including patch files.</summary>
+            public List<string> Paths = new();
+            /// <summary>Applied patch file paths.</summary>
+            public List<string> PatchPaths = new();
+            /// <summary>Total size of read files.</summary>
+            public long ByteSize;
+            /// <summary>Number of entries of first-level maps and lists.</summary>
+            public int EntryCount;
         }
 
         protected Stats LoadStats = new();
@@ -368,6 +466,12 @@ namespace Tableau
         /// ProcessAfterLoadAll is invoked after all messagers loaded.
         /// </summary>
         public virtual bool ProcessAfterLoadAll(in Hub hub) => true;
+
+        /// <summary>
+        /// Dependencies returns the names of messagers whose ProcessAfterLoadAll
+        /// must be invoked before this messager's.
+        /// </summary>
+        public virtual IReadOnlyList<string> Dependencies() => Array.Empty<string>();
     }
 }
 
@@ -30,10 +30,11 @@ namespace Tableau
         public override bool Load(string dir, Format fmt, in Load.MessagerOptions? options = null)
         {
             var start = DateTime.Now;
+            LoadStats = new Stats();
             try
             {
                 _data = (Protoconf.PatchReplaceConf)(
-                    Tableau.Load.LoadMessagerInDir(Protoconf.PatchReplaceConf.Descriptor, dir, fmt, options)
+                    Tableau.Load.LoadMessagerInDir(Protoconf.PatchReplaceConf.Descriptor, dir, fmt, options, LoadStats)
                     ?? throw new InvalidOperationException()
                 );
             }
@@ -45,8 +46,11 @@ namespace Tableau
                 }
                 return false;
             }
+            var processStart = DateTime.Now;
+            bool ok = ProcessAfterLoad();
+            LoadStats.ProcessAfterLoadDuration = DateTime.Now - processStart;
             LoadStats.Duration = DateTime.Now - start;
-            return ProcessAfterLoad();
+            return ok;
         }
 
         /// <summary>
@@ -78,10 +82,11 @@ namespace Tableau
         public override bool Load(string dir, Format fmt, in Load.MessagerOptions? options = null)
         {
             var start = DateTime.Now;
+            LoadStats = new Stats();
             try
             {
                 _data = (Protoconf.PatchMergeConf)(
-                    Tableau.Load.LoadMessagerInDir(Protoconf.PatchMergeConf.Descriptor, dir, fmt, options)
+                    Tableau.Load.LoadMessagerInDir(Protoconf.PatchMergeConf.Descriptor, dir, fmt, options, LoadStats)
                     ?? throw new InvalidOperationException()
                 );
             }
@@ -93,8 +98,11 @@ namespace Tableau
                 }
                 return false;
             }
+            var processStart = DateTime.Now;
+            bool ok = ProcessAfterLoad();
+            LoadStats.ProcessAfterLoadDuration = DateTime.Now - processStart;
             LoadStats.Duration = DateTime.Now - start;
-            return ProcessAfterLoad();
+            return ok;
         }
 
         /// <summary>
@@ -133,10 +141,11 @@ namespace Tableau
         public override bool Load(string dir, Format fmt, in Load.MessagerOptions? options = null)
         {
             var start = DateTime.Now;
+            LoadStats = new Stats();
             try
             {
                 _data = (Protoconf.RecursivePatchConf)(
-                    Tableau.Load.LoadMessagerInDir(Protoconf.RecursivePatchConf.Descriptor, dir, fmt, options)
+                    Tableau.Load.LoadMessagerInDir(Protoconf.RecursivePatchConf.Descriptor, dir, fmt, options, LoadStats)
                     ?? throw new InvalidOperationException()
                 );
             }
@@ -148,8 +157,11 @@ namespace Tableau
                 }
                 return false;
             }
+            var processStart = DateTime.Now;
+            bool ok = ProcessAfterLoad();
+            LoadStats.ProcessAfterLoadDuration = DateTime.Now - processStart;
             LoadStats.Duration = DateTime.Now - start;
-            return ProcessAfterLoad();
+            return ok;
         }
 
         /// <summary>
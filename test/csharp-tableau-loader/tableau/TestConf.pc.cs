@@ -124,10 +124,11 @@ namespace Tableau
         public override bool Load(string dir, Format fmt, in Load.MessagerOptions? options = null)
         {
             var start = DateTime.Now;
+            LoadStats = new Stats();
             try
             {
                 _data = (Protoconf.ActivityConf)(
-                    Tableau.Load.LoadMessagerInDir(Protoconf.ActivityConf.Descriptor, dir, fmt, options)
+                    Tableau.Load.LoadMessagerInDir(Protoconf.ActivityConf.Descriptor, dir, fmt, options, LoadStats)
                     ?? throw new InvalidOperationException()
                 );
             }
@@ -139,8 +140,11 @@ namespace Tableau
                 }
                 return false;
             }
+            var processStart = DateTime.Now;
+            bool ok = ProcessAfterLoad();
+            LoadStats.ProcessAfterLoadDuration = DateTime.Now - processStart;
             LoadStats.Duration = DateTime.Now - start;
-            return ProcessAfterLoad();
+            return ok;
         }
 
         /// <summary>
@@ -562,10 +566,11 @@ namespace Tableau
         public override bool Load(string dir, Format fmt, in Load.MessagerOptions? options = null)
         {
             var start = DateTime.Now;
+            LoadStats = new Stats();
             try
             {
                 _data = (Protoconf.ChapterConf)(
-                    Tableau.Load.LoadMessagerInDir(Protoconf.ChapterConf.Descriptor, dir, fmt, options)
+                    Tableau.Load.LoadMessagerInDir(Protoconf.ChapterConf.Descriptor, dir, fmt, options, LoadStats)
                     ?? throw new InvalidOperationException()
                 );
             }
@@ -577,8 +582,11 @@ namespace Tableau
                 }
                 return false;
             }
+            var processStart = DateTime.Now;
+            bool ok = ProcessAfterLoad();
+            LoadStats.ProcessAfterLoadDuration = DateTime.Now - processStart;
             LoadStats.Duration = DateTime.Now - start;
-            return ProcessAfterLoad();
+            return ok;
         }
 
         /// <summary>
@@ -617,10 +625,11 @@ namespace Tableau
         public override bool Load(string dir, Format fmt, in Load.MessagerOptions? options = null)
         {
             var start = DateTime.Now;
+            LoadStats = new Stats();
             try
             {
                 _data = (Protoconf.ThemeConf)(
-                    Tableau.Load.LoadMessagerInDir(Protoconf.ThemeConf.Descriptor, dir, fmt, options)
+                    Tableau.Load.LoadMessagerInDir(Protoconf.ThemeConf.Descriptor, dir, fmt, options, LoadStats)
                     ?? throw new InvalidOperationException()
                 );
             }
@@ -632,8 +641,11 @@ namespace Tableau
                 }
                 return false;
             }
+            var processStart = DateTime.Now;
+            bool ok = ProcessAfterLoad();
+            LoadStats.ProcessAfterLoadDuration = DateTime.Now - processStart;
             LoadStats.Duration = DateTime.Now - start;
-            return ProcessAfterLoad();
+            return ok;
         }
 
         /// <summary>
@@ -721,10 +733,11 @@ namespace Tableau
         public override bool Load(string dir, Format fmt, in Load.MessagerOptions? options = null)
         {
             var start = DateTime.Now;
+            LoadStats = new Stats();
             try
             {
                 _data = (Protoconf.TaskConf)(
-                    Tableau.Load.LoadMessagerInDir(Protoconf.TaskConf.Descriptor, dir, fmt, options)
+                    Tableau.Load.LoadMessagerInDir(Protoconf.TaskConf.Descriptor, dir, fmt, options, LoadStats)
                     ?? throw new InvalidOperationException()
                 );
             }
@@ -736,8 +749,11 @@ namespace Tableau
                 }
                 return false;
             }
+            var processStart = DateTime.Now;
+            bool ok = ProcessAfterLoad();
+            LoadStats.ProcessAfterLoadDuration = DateTime.Now - processStart;
             LoadStats.Duration = DateTime.Now - start;
-            return ProcessAfterLoad();
+            return ok;
         }
 
         /// <summary>
@@ -1019,10 +1035,11 @@ namespace Tableau
         public override bool Load(string dir, Format fmt, in Load.MessagerOptions? options = null)
         {
             var start = DateTime.Now;
+            LoadStats = new Stats();
             try
             {
                 _data = (Protoconf.StrcaseConf)(
-                    Tableau.Load.LoadMessagerInDir(Protoconf.StrcaseConf.Descriptor, dir, fmt, options)
+                    Tableau.Load.LoadMessagerInDir(Protoconf.StrcaseConf.Descriptor, dir, fmt, options, LoadStats)
                     ?? throw new InvalidOperationException()
                 );
             }
@@ -1034,8 +1051,11 @@ namespace Tableau
                 }
                 return false;
             }
+            var processStart = DateTime.Now;
+            bool ok = ProcessAfterLoad();
+            LoadStats.ProcessAfterLoadDuration = DateTime.Now - processStart;
             LoadStats.Duration = DateTime.Now - start;
-            return ProcessAfterLoad();
+            return ok;
         }
 
         /// <summary>
@@ -0,0 +1,102 @@
+using System;
+using System.Collections.Generic;
+using System.IO;
+using Xunit;
+
+namespace LoaderTests
+{
+    /// <summary>
+    /// Messager dependency sorting tests, mirroring
+    /// test/go-tableau-loader/dependency_test.go.
+    /// </summary>
+    [Collection("HubCollection")]
+    public class DependencyTests
+    {
+        /// <summary>
+        /// DependencyTestConf is a messager with the given dependencies only.
+        /// </summary>
+        private class DependencyTestConf : Tableau.Messager
+        {
+            private readonly string[] _deps;
+
+            public DependencyTestConf(params string[] deps)
+            {
+                _deps = deps;
+            }
+
+            public override bool Load(string dir, Tableau.Format fmt, in Tableau.Load.MessagerOptions? options = null) => true;
+
+            public override IReadOnlyList<string> Dependencies() => _deps;
+        }
+
+        /// <summary>
+        /// Sort sorts the messagers, and returns the sorted names and the error
+        /// output written by the sorting.
+        /// </summary>
+        private static (List<string>? names, string errOutput) Sort(Dictionary<string, Tableau.Messager> messagerMap)
+        {
+            var stderr = Console.Error;
+            var writer = new StringWriter();
+            Console.SetError(writer);
+            try
+            {
+                return (Tableau.Hub.SortMessagersByDependencies(messagerMap), writer.ToString());
+            }
+            finally
+            {
+                Console.SetError(stderr);
+            }
+        }
+
+        [Fact]
+        public void SortMessagersByDependencies_Order()
+        {
+            // AConf is placed before BConf in name order, but it depends on
+            // BConf, so it must be processed after.
+            var (names, errOutput) = Sort(new Dictionary<string, Tableau.Messager>
+            {
+                ["AConf"] = new DependencyTestConf("BConf"),
+                ["BConf"] = new DependencyTestConf("CConf"),
+                ["CConf"] = new DependencyTestConf(),
+                ["DConf"] = new DependencyTestConf(),
+            });
+            Assert.True(names != null, errOutput);
+            Assert.Equal(new[] { "CConf", "BConf", "AConf", "DConf" }, names);
+        }
+
+        [Fact]
+        public void SortMessagersByDependencies_Missing()
+        {
+            var (names, errOutput) = Sort(new Dictionary<string, Tableau.Messager>
+            {
+                ["AConf"] = new DependencyTestConf("BConf"),
+            });
+            Assert.Null(names);
+            Assert.Contains("AConf depends on BConf", errOutput);
+        }
+
+        [Fact]
+        public void SortMessagersByDependencies_Cycle()
+        {
+            var (names, errOutput) = Sort(new Dictionary<string, Tableau.Messager>
+            {
+                ["AConf"] = new DependencyTestConf("BConf"),
+                ["BConf"] = new DependencyTestConf("CConf"),
+                ["CConf"] = new DependencyTestConf("BConf"),
+            });
+            Assert.Null(names);
+            Assert.Contains("BConf -> CConf -> BConf", errOutput);
+        }
+
+        [Fact]
+        public void SortMessagersByDependencies_SelfCycle()
+        {
+            var (names, errOutput) = Sort(new Dictionary<string, Tableau.Messager>
+            {
+                ["AConf"] = new DependencyTestConf("AConf"),
+            });
+            Assert.Null(names);
+            Assert.Contains("AConf -> AConf", errOutput);
+        }
+    }
+}
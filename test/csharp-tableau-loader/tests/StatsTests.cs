@@ -0,0 +1,69 @@
+using System.Collections.Generic;
+using System.IO;
+using Xunit;
+
+namespace LoaderTests
+{
+    /// <summary>
+    /// Loading stats tests, mirroring:
+    ///   - Go:  test/go-tableau-loader/main_test.go::Test_Stats
+    ///   - C++: test/cpp-tableau-loader/tests/stats_test.cpp
+    /// </summary>
+    public class StatsTests
+    {
+        private static readonly string MainPath = Path.Combine(TestPaths.ConfDir, "PatchMergeConf.json");
+        private static readonly string PatchPath = Path.Combine(TestPaths.PatchConfDir, "PatchMergeConf.json");
+
+        private static Tableau.Load.MessagerOptions NewOptions() => new Tableau.Load.MessagerOptions
+        {
+            IgnoreUnknownFields = true,
+            PatchDirs = new List<string> { TestPaths.PatchConfDir },
+        };
+
+        private static void AssertPatchMergeConfStats(Protoconf.PatchMergeConf? msg, Tableau.Messager.Stats stats)
+        {
+            Assert.NotNull(msg);
+            Assert.Equal(new[] { MainPath, PatchPath }, stats.Paths);
+            Assert.Equal(new[] { PatchPath }, stats.PatchPaths);
+            Assert.Equal(new FileInfo(MainPath).Length + new FileInfo(PatchPath).Length, stats.ByteSize);
+            Assert.Equal(msg!.PriceList.Count + msg.ReplacePriceList.Count + msg.ItemMap.Count + msg.ReplaceItemMap.Count, stats.EntryCount);
+            Assert.True(stats.ReadDuration > System.TimeSpan.Zero, "read duration should be filled");
+            Assert.True(stats.PatchDuration > System.TimeSpan.Zero, "patch duration should be filled");
+        }
+
+        [Fact]
+        public void LoadMessagerInDir_FillsStatsBreakdown()
+        {
+            var stats = new Tableau.Messager.Stats();
+            var msg = Tableau.Load.LoadMessagerInDir(Protoconf.PatchMergeConf.Descriptor, TestPaths.ConfDir, Tableau.Format.JSON, NewOptions(), stats);
+            Assert.True(msg != null, $"failed to load: {Tableau.Util.GetErrMsg()}");
+            AssertPatchMergeConfStats(msg as Protoconf.PatchMergeConf, stats);
+        }
+
+        [Fact]
+        public void LoadMessagerInDir_EmptyPathLoadsFromDir()
+        {
+            // an empty Path is the same as no Path, so the main file is not
+            // counted as a patch file
+            var options = NewOptions();
+            options.Path = "";
+            var stats = new Tableau.Messager.Stats();
+            var msg = Tableau.Load.LoadMessagerInDir(Protoconf.PatchMergeConf.Descriptor, TestPaths.ConfDir, Tableau.Format.JSON, options, stats);
+            Assert.True(msg != null, $"failed to load: {Tableau.Util.GetErrMsg()}");
+            AssertPatchMergeConfStats(msg as Protoconf.PatchMergeConf, stats);
+        }
+
+        [Fact]
+        public void LoadMessagerInDir_NoPatch()
+        {
+            var options = new Tableau.Load.MessagerOptions { IgnoreUnknownFields = true };
+            var stats = new Tableau.Messager.Stats();
+            var msg = Tableau.Load.LoadMessagerInDir(Protoconf.ItemConf.Descriptor, TestPaths.ConfDir, Tableau.Format.JSON, options, stats) as Protoconf.ItemConf;
+            Assert.True(msg != null, $"failed to load: {Tableau.Util.GetErrMsg()}");
+            Assert.Equal(new[] { Path.Combine(TestPaths.ConfDir, "ItemConf.json") }, stats.Paths);
+            Assert.Empty(stats.PatchPaths);
+            Assert.Equal(System.TimeSpan.Zero, stats.PatchDuration);
+            Assert.Equal(msg!.ItemMap.Count, stats.EntryCount);
+        }
+    }
+}
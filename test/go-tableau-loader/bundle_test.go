@@ -0,0 +1,242 @@
+package loader_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/tableauio/loader/test/go-tableau-loader/hub"
+	"github.com/tableauio/loader/test/go-tableau-loader/protoconf/loader"
+	"github.com/tableauio/tableau/format"
+	"github.com/tableauio/tableau/load"
+	"google.golang.org/protobuf/proto"
+)
+
+func Test_Bundle(t *testing.T) {
+	h := prepareHub(t)
+	for _, fmt := range []format.Format{format.JSON, format.Bin, format.Text} {
+		var buf bytes.Buffer
+		if err := h.StoreBundle(&buf, fmt); err != nil {
+			t.Fatalf("failed to store bundle in %s: %v", fmt, err)
+		}
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatalf("failed to open bundle: %v", err)
+		}
+		manifest, err := loader.ReadBundleManifest(zr)
+		if err != nil {
+			t.Fatalf("failed to read manifest: %v", err)
+		}
+		if manifest.Format != fmt || manifest.PluginVersion != loader.PluginVersion || len(manifest.Messagers) == 0 {
+			t.Fatalf("unexpected manifest: %+v", manifest)
+		}
+
+		loaded := hub.NewMyHub()
+		if err := loaded.LoadBundle(bytes.NewReader(buf.Bytes()), load.IgnoreUnknownFields()); err != nil {
+			t.Fatalf("failed to load bundle in %s: %v", fmt, err)
+		}
+		for _, entry := range manifest.Messagers {
+			if !proto.Equal(loaded.GetMessager(entry.Name).Message(), h.GetMessager(entry.Name).Message()) {
+				t.Fatalf("%s loaded from bundle in %s differs", entry.Name, fmt)
+			}
+		}
+		if loaded.GetCustomItemConf().GetSpecialItemName() != h.GetCustomItemConf().GetSpecialItemName() {
+			t.Fatal("CustomItemConf should be processed after loading bundle")
+		}
+	}
+}
+
+// repackBundle repacks the bundle with each file edited by edit, and the
+// extra files added.
+func repackBundle(t *testing.T, bundle []byte, edit func(name string, content []byte) []byte, extra map[string][]byte) *bytes.Buffer {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(bundle), int64(len(bundle)))
+	if err != nil {
+		t.Fatalf("failed to open bundle: %v", err)
+	}
+	var repacked bytes.Buffer
+	zw := zip.NewWriter(&repacked)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", f.Name, err)
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", f.Name, err)
+		}
+		fw, err := zw.Create(f.Name)
+		if err != nil {
+			t.Fatalf("failed to create %s: %v", f.Name, err)
+		}
+		if _, err := fw.Write(edit(f.Name, content)); err != nil {
+			t.Fatalf("failed to write %s: %v", f.Name, err)
+		}
+	}
+	for name, content := range extra {
+		fw, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+		if _, err := fw.Write(content); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("failed to close bundle: %v", err)
+	}
+	return &repacked
+}
+
+// editManifest returns an edit func of [repackBundle] which edits the
+// manifest only.
+func editManifest(t *testing.T, edit func(manifest *loader.BundleManifest)) func(name string, content []byte) []byte {
+	return func(name string, content []byte) []byte {
+		if name != loader.BundleManifestPath {
+			return content
+		}
+		var manifest loader.BundleManifest
+		if err := json.Unmarshal(content, &manifest); err != nil {
+			t.Fatalf("failed to parse manifest: %v", err)
+		}
+		edit(&manifest)
+		content, err := json.Marshal(&manifest)
+		if err != nil {
+			t.Fatalf("failed to marshal manifest: %v", err)
+		}
+		return content
+	}
+}
+
+func Test_Bundle_ChecksumMismatch(t *testing.T) {
+	h := prepareHub(t)
+	var buf bytes.Buffer
+	if err := h.StoreBundle(&buf, format.JSON); err != nil {
+		t.Fatalf("failed to store bundle: %v", err)
+	}
+	// repack the bundle with a tampered ItemConf
+	tampered := repackBundle(t, buf.Bytes(), func(name string, content []byte) []byte {
+		if name == "ItemConf.json" {
+			content = append(content, ' ')
+		}
+		return content
+	}, nil)
+
+	loaded := hub.NewMyHub()
+	err := loaded.LoadBundle(tampered)
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch of ItemConf") {
+		t.Fatalf("expected checksum mismatch, got: %v", err)
+	}
+	if loaded.GetItemConf() != nil {
+		t.Fatal("no container should be built from a tampered bundle")
+	}
+}
+
+func Test_Bundle_PluginVersionMismatch(t *testing.T) {
+	h := prepareHub(t)
+	var buf bytes.Buffer
+	if err := h.StoreBundle(&buf, format.JSON); err != nil {
+		t.Fatalf("failed to store bundle: %v", err)
+	}
+	repacked := repackBundle(t, buf.Bytes(), editManifest(t, func(manifest *loader.BundleManifest) {
+		manifest.PluginVersion = "v0.0.0"
+	}), nil)
+	err := hub.NewMyHub().LoadBundle(repacked)
+	if err == nil || !strings.Contains(err.Error(), "plugin version mismatch") {
+		t.Fatalf("expected plugin version mismatch, got: %v", err)
+	}
+}
+
+func Test_Bundle_OnlyManifestEntries(t *testing.T) {
+	h := prepareHub(t)
+	var buf bytes.Buffer
+	if err := h.StoreBundle(&buf, format.JSON); err != nil {
+		t.Fatalf("failed to store bundle: %v", err)
+	}
+	// unlist PatchReplaceConf, whose config file is still in the bundle
+	repacked := repackBundle(t, buf.Bytes(), editManifest(t, func(manifest *loader.BundleManifest) {
+		manifest.Messagers = slices.DeleteFunc(manifest.Messagers, func(entry loader.BundleEntry) bool {
+			return entry.Name == "PatchReplaceConf"
+		})
+	}), nil)
+	loaded := hub.NewMyHub()
+	if err := loaded.LoadBundle(repacked, load.IgnoreUnknownFields()); err != nil {
+		t.Fatalf("failed to load bundle: %v", err)
+	}
+	if loaded.GetPatchReplaceConf() != nil {
+		t.Fatal("PatchReplaceConf not listed in manifest should not be loaded")
+	}
+	if loaded.GetItemConf() == nil || loaded.GetCustomItemConf() == nil {
+		t.Fatal("listed and custom messagers should be loaded")
+	}
+}
+
+func Test_Bundle_TamperedUnverifiedMember(t *testing.T) {
+	h := prepareHub(t)
+	var buf bytes.Buffer
+	if err := h.StoreBundle(&buf, format.JSON); err != nil {
+		t.Fatalf("failed to store bundle: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("failed to open bundle: %v", err)
+	}
+	verified, err := fs.ReadFile(zr, "ItemConf.json")
+	if err != nil {
+		t.Fatalf("failed to read ItemConf.json: %v", err)
+	}
+	// list a verified extra member, while tampering the default one
+	repacked := repackBundle(t, buf.Bytes(), func(name string, content []byte) []byte {
+		switch name {
+		case "ItemConf.json":
+			return bytes.ReplaceAll(content, []byte(`"apple"`), []byte(`"tampered"`))
+		case loader.BundleManifestPath:
+			return editManifest(t, func(manifest *loader.BundleManifest) {
+				for i := range manifest.Messagers {
+					if manifest.Messagers[i].Name == "ItemConf" {
+						manifest.Messagers[i].Path = "verified/ItemConf.json"
+					}
+				}
+			})(name, content)
+		default:
+			return content
+		}
+	}, map[string][]byte{"verified/ItemConf.json": verified})
+
+	loaded := hub.NewMyHub()
+	if err := loaded.LoadBundle(repacked, load.IgnoreUnknownFields()); err != nil {
+		t.Fatalf("failed to load bundle: %v", err)
+	}
+	if !proto.Equal(loaded.GetItemConf().Data(), h.GetItemConf().Data()) {
+		t.Fatalf("ItemConf should be loaded from the verified member, got: %v", loaded.GetItemConf().Data())
+	}
+	if got := loaded.GetItemConf().GetStats().Paths; !slices.Equal(got, []string{"verified/ItemConf.json"}) {
+		t.Fatalf("unexpected paths of ItemConf: %v", got)
+	}
+}
+
+func Test_Bundle_UnlistedDependency(t *testing.T) {
+	h := hub.NewMyHub(withDependencyTestConf("ItemConf"))
+	if err := h.Load("../testdata/conf/", format.JSON, load.IgnoreUnknownFields()); err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := h.StoreBundle(&buf, format.JSON); err != nil {
+		t.Fatalf("failed to store bundle: %v", err)
+	}
+	repacked := repackBundle(t, buf.Bytes(), editManifest(t, func(manifest *loader.BundleManifest) {
+		manifest.Messagers = slices.DeleteFunc(manifest.Messagers, func(entry loader.BundleEntry) bool {
+			return entry.Name == "ItemConf"
+		})
+	}), nil)
+	err := hub.NewMyHub(withDependencyTestConf("ItemConf")).LoadBundle(repacked, load.IgnoreUnknownFields())
+	if err == nil || !strings.Contains(err.Error(), "leaves out ItemConf") {
+		t.Fatalf("expected unlisted dependency error, got: %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+package customconf
+
+import (
+	"fmt"
+
+	tableau "github.com/tableauio/loader/test/go-tableau-loader/protoconf/loader"
+)
+
+const CustomAwardItemConfName = "CustomAwardItemConf"
+
+// CustomAwardItemConf derives its data from CustomItemConf, so it must be
+// processed after CustomItemConf.
+type CustomAwardItemConf struct {
+	tableau.UnimplementedMessager
+	awardItemTitle string
+}
+
+func (x *CustomAwardItemConf) Name() string {
+	return CustomAwardItemConfName
+}
+
+func (x *CustomAwardItemConf) Dependencies() []string {
+	return []string{CustomItemConfName}
+}
+
+func (x *CustomAwardItemConf) ProcessAfterLoadAll(hub *tableau.Hub) error {
+	conf, ok := hub.GetMessager(CustomItemConfName).(*CustomItemConf)
+	if !ok || conf.specialItemConf == nil {
+		return fmt.Errorf("%s not processed yet", CustomItemConfName)
+	}
+	x.awardItemTitle = fmt.Sprintf("[Award] %s", conf.GetSpecialItemName())
+	return nil
+}
+
+func (x *CustomAwardItemConf) GetAwardItemTitle() string {
+	return x.awardItemTitle
+}
+
+func init() {
+	tableau.Register(func() tableau.Messager {
+		return new(CustomAwardItemConf)
+	})
+}
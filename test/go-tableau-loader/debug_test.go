@@ -0,0 +1,94 @@
+package loader_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tableauio/loader/test/go-tableau-loader/hub"
+	"github.com/tableauio/tableau/format"
+	"github.com/tableauio/tableau/load"
+)
+
+func Test_DebugHandler(t *testing.T) {
+	h := hub.NewMyHub()
+	if err := h.Load("../testdata/conf/", format.JSON, load.IgnoreUnknownFields()); err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	server := httptest.NewServer(h.DebugHandler())
+	defer server.Close()
+
+	get := func(path string) (int, string) {
+		resp, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("failed to get %s: %v", path, err)
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", path, err)
+		}
+		return resp.StatusCode, string(body)
+	}
+
+	status, body := get("/")
+	var list struct {
+		Generation uint64 `json:"generation"`
+		Messagers  []struct {
+			Name    string   `json:"name"`
+			Indexes []string `json:"indexes"`
+		} `json:"messagers"`
+	}
+	if status != http.StatusOK {
+		t.Fatalf("unexpected status %d: %s", status, body)
+	}
+	if err := json.Unmarshal([]byte(body), &list); err != nil {
+		t.Fatalf("failed to unmarshal list: %v", err)
+	}
+	if list.Generation != h.Generation() {
+		t.Errorf("generation: got %d, want %d", list.Generation, h.Generation())
+	}
+	var itemIndexes []string
+	for _, msger := range list.Messagers {
+		if msger.Name == "ItemConf" {
+			itemIndexes = msger.Indexes
+		}
+	}
+	if !strings.Contains(strings.Join(itemIndexes, ","), "Item,") {
+		t.Errorf("unexpected indexes of ItemConf: %v", itemIndexes)
+	}
+
+	tests := []struct {
+		path   string
+		status int
+		want   string
+	}{
+		{"/ItemConf", http.StatusOK, `"itemMap"`},
+		{"/ItemConf/get?key=1", http.StatusOK, `"name": "apple"`},
+		{"/ItemConf/get?key=999", http.StatusNotFound, "not found"},
+		{"/ItemConf/get?key=abc", http.StatusBadRequest, "invalid key"},
+		{"/ItemConf/find/Item?key=FRUIT_TYPE_APPLE", http.StatusOK, `"name": "apple"`},
+		{"/ItemConf/find/Item?key=1", http.StatusOK, `"name": "apple"`},
+		{"/ItemConf/find/Item", http.StatusBadRequest, "requires 1 keys"},
+		{"/ItemConf/find/NotExist?key=1", http.StatusNotFound, "not found"},
+		{"/NotExistConf", http.StatusNotFound, "not found"},
+	}
+	for _, tt := range tests {
+		status, body := get(tt.path)
+		if status != tt.status || !strings.Contains(body, tt.want) {
+			t.Errorf("GET %s: got %d %s, want %d containing %q", tt.path, status, body, tt.status, tt.want)
+		}
+	}
+
+	resp, err := http.Post(server.URL+"/ItemConf", "application/json", nil)
+	if err != nil {
+		t.Fatalf("failed to post: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("POST: got %d, want %d", resp.StatusCode, http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,76 @@
+package loader_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tableauio/loader/test/go-tableau-loader/customconf"
+	"github.com/tableauio/loader/test/go-tableau-loader/hub"
+	"github.com/tableauio/loader/test/go-tableau-loader/protoconf/loader"
+	"github.com/tableauio/tableau/format"
+	"github.com/tableauio/tableau/load"
+)
+
+const dependencyTestConfName = "DependencyTestConf"
+
+type DependencyTestConf struct {
+	loader.UnimplementedMessager
+	deps []string
+}
+
+func (x *DependencyTestConf) Name() string {
+	return dependencyTestConfName
+}
+
+func (x *DependencyTestConf) Dependencies() []string {
+	return x.deps
+}
+
+// withDependencyTestConf registers DependencyTestConf with the given
+// dependencies to the hub only.
+func withDependencyTestConf(deps ...string) loader.Option {
+	return loader.WithMessager(func() loader.Messager {
+		return &DependencyTestConf{deps: deps}
+	})
+}
+
+func Test_Dependencies(t *testing.T) {
+	// CustomAwardItemConf is placed before CustomItemConf in name order,
+	// but it depends on CustomItemConf, so it must be processed after.
+	h := prepareHub(t)
+	if got := h.GetCustomAwardItemConf().GetAwardItemTitle(); got != "[Award] "+h.GetCustomItemConf().GetSpecialItemName() {
+		t.Fatalf("unexpected award item title: %q", got)
+	}
+}
+
+func Test_Dependencies_Missing(t *testing.T) {
+	h := hub.NewMyHub(loader.Filter(func(name string) bool {
+		return name != customconf.CustomItemConfName
+	}))
+	err := h.Load("../testdata/conf/", format.JSON, load.IgnoreUnknownFields())
+	if err == nil || !strings.Contains(err.Error(), "depends on CustomItemConf") {
+		t.Fatalf("expected missing dependency error, got: %v", err)
+	}
+}
+
+func Test_Dependencies_Cycle(t *testing.T) {
+	h := hub.NewMyHub(withDependencyTestConf(customconf.CustomAwardItemConfName, dependencyTestConfName))
+	err := h.Load("../testdata/conf/", format.JSON, load.IgnoreUnknownFields())
+	if err == nil || !strings.Contains(err.Error(), "DependencyTestConf -> DependencyTestConf") {
+		t.Fatalf("expected dependency cycle error, got: %v", err)
+	}
+}
+
+func Test_Dependencies_PerHub(t *testing.T) {
+	h := hub.NewMyHub(withDependencyTestConf(customconf.CustomAwardItemConfName))
+	if err := h.Load("../testdata/conf/", format.JSON, load.IgnoreUnknownFields()); err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if h.GetMessager(dependencyTestConfName) == nil {
+		t.Fatalf("%s not registered to the hub", dependencyTestConfName)
+	}
+	// messagers registered to a hub do not leak into other hubs
+	if prepareHub(t).GetMessager(dependencyTestConfName) != nil {
+		t.Fatalf("%s leaked into another hub", dependencyTestConfName)
+	}
+}
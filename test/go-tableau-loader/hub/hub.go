@@ -16,9 +16,9 @@ var hubSingleton *MyHub
 var once sync.Once
 
 // NewMyHub creates a new MyHub instance (useful for testing).
-func NewMyHub() *MyHub {
+func NewMyHub(options ...tableau.Option) *MyHub {
 	return &MyHub{
-		Hub: tableau.NewHub(),
+		Hub: tableau.NewHub(options...),
 	}
 }
 
@@ -46,3 +46,13 @@ func (h *MyHub) GetCustomItemConf() *customconf.CustomItemConf {
 	}
 	return nil
 }
+
+func (h *MyHub) GetCustomAwardItemConf() *customconf.CustomAwardItemConf {
+	msger := h.GetMessager(customconf.CustomAwardItemConfName)
+	if msger != nil {
+		if conf, ok := msger.(*customconf.CustomAwardItemConf); ok {
+			return conf
+		}
+	}
+	return nil
+}
@@ -1,15 +1,32 @@
 package loader_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"testing/fstest"
+	"time"
 
+	"github.com/tableauio/loader/pkg/udiff"
+	"github.com/tableauio/loader/test/go-tableau-loader/customconf"
 	"github.com/tableauio/loader/test/go-tableau-loader/hub"
+	"github.com/tableauio/loader/test/go-tableau-loader/protoconf"
 	"github.com/tableauio/loader/test/go-tableau-loader/protoconf/loader"
 	"github.com/tableauio/tableau/format"
 	"github.com/tableauio/tableau/load"
 	"github.com/tableauio/tableau/store"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -289,3 +306,1094 @@ func Test_Patch(t *testing.T) {
 }
 
 func modeRef(m load.LoadMode) *load.LoadMode { return &m }
+
+func Test_LoadConcurrency(t *testing.T) {
+	loadOptions := []load.Option{
+		load.IgnoreUnknownFields(),
+		load.PatchDirs("../testdata/patchconf/"),
+	}
+	sequential := hub.NewMyHub()
+	if err := sequential.Load("../testdata/conf/", format.JSON, loadOptions...); err != nil {
+		t.Fatalf("failed to load sequentially: %v", err)
+	}
+	concurrent := hub.NewMyHub(loader.WithLoadConcurrency(4))
+	if err := concurrent.Load("../testdata/conf/", format.JSON, loadOptions...); err != nil {
+		t.Fatalf("failed to load concurrently: %v", err)
+	}
+
+	seqMap, conMap := sequential.GetMessagerMap(), concurrent.GetMessagerMap()
+	if len(seqMap) != len(conMap) {
+		t.Fatalf("messager count mismatch: sequential %d, concurrent %d", len(seqMap), len(conMap))
+	}
+	for name, msger := range seqMap {
+		other, ok := conMap[name]
+		if !ok {
+			t.Fatalf("messager %s not loaded concurrently", name)
+		}
+		if !proto.Equal(msger.Message(), other.Message()) {
+			t.Fatalf("messager %s mismatch:\n sequential: %v\n concurrent: %v", name, msger.Message(), other.Message())
+		}
+		if msger.GetStats().Duration != 0 && other.GetStats().Duration == 0 {
+			t.Fatalf("messager %s stats not filled in concurrently", name)
+		}
+	}
+	if concurrent.GetCustomItemConf().GetSpecialItemName() != sequential.GetCustomItemConf().GetSpecialItemName() {
+		t.Fatal("ProcessAfterLoadAll result mismatch")
+	}
+}
+
+func Test_LoadConcurrency_Error(t *testing.T) {
+	sequential := hub.NewMyHub()
+	seqErr := sequential.Load("../testdata/not-exist/", format.JSON)
+	if seqErr == nil {
+		t.Fatal("expected error when loading sequentially from a non-existent dir")
+	}
+	concurrent := hub.NewMyHub(loader.WithLoadConcurrency(8))
+	conErr := concurrent.Load("../testdata/not-exist/", format.JSON)
+	if conErr == nil {
+		t.Fatal("expected error when loading concurrently from a non-existent dir")
+	}
+	if seqErr.Error() != conErr.Error() {
+		t.Fatalf("error mismatch:\n sequential: %v\n concurrent: %v", seqErr, conErr)
+	}
+	if len(concurrent.GetMessagerMap()) != 0 {
+		t.Fatal("container should be untouched on load failure")
+	}
+}
+
+func Test_PrepareAndCommit(t *testing.T) {
+	h := prepareHub(t)
+	old := h.GetPatchReplaceConf()
+
+	pending, err := h.Prepare("../testdata/conf/", format.JSON,
+		load.IgnoreUnknownFields(),
+		load.PatchDirs("../testdata/patchconf/"),
+	)
+	if err != nil {
+		t.Fatalf("failed to prepare: %v", err)
+	}
+	staged := pending.Container().GetPatchReplaceConf()
+	if h.GetPatchReplaceConf() != old {
+		t.Fatal("hub should not change before commit")
+	}
+	if proto.Equal(staged.Data(), old.Data()) {
+		t.Fatal("staged container should be loaded with patch")
+	}
+
+	if err := pending.Commit(); err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+	if h.GetPatchReplaceConf() != staged {
+		t.Fatal("hub should take the staged container into effect after commit")
+	}
+	if err := pending.Commit(); !errors.Is(err, loader.ErrPendingDone) {
+		t.Fatalf("expected ErrPendingDone, got: %v", err)
+	}
+}
+
+func Test_PrepareAndDiscard(t *testing.T) {
+	h := prepareHub(t)
+	old := h.GetItemConf()
+
+	pending, err := h.Prepare("../testdata/conf/", format.JSON, load.IgnoreUnknownFields())
+	if err != nil {
+		t.Fatalf("failed to prepare: %v", err)
+	}
+	pending.Discard()
+	if err := pending.Commit(); !errors.Is(err, loader.ErrPendingDone) {
+		t.Fatalf("expected ErrPendingDone, got: %v", err)
+	}
+	if h.GetItemConf() != old {
+		t.Fatal("hub should not change after discard")
+	}
+}
+
+func Test_Reload(t *testing.T) {
+	h := prepareHub(t)
+	oldItemConf := h.GetItemConf()
+	oldPatchReplaceConf := h.GetPatchReplaceConf()
+	oldCustomItemConf := h.GetCustomItemConf()
+
+	err := h.Reload("../testdata/conf/", format.JSON, []string{"PatchReplaceConf"},
+		load.IgnoreUnknownFields(),
+		load.PatchDirs("../testdata/patchconf/"),
+	)
+	if err != nil {
+		t.Fatalf("failed to reload: %v", err)
+	}
+	if h.GetItemConf() != oldItemConf {
+		t.Fatal("ItemConf should be reused")
+	}
+	if h.GetPatchReplaceConf() == oldPatchReplaceConf {
+		t.Fatal("PatchReplaceConf should be reloaded")
+	}
+	if proto.Equal(h.GetPatchReplaceConf().Data(), oldPatchReplaceConf.Data()) {
+		t.Fatal("PatchReplaceConf should be reloaded with patch")
+	}
+	customItemConf := h.GetCustomItemConf()
+	if customItemConf == oldCustomItemConf {
+		t.Fatal("CustomItemConf should be created anew")
+	}
+	if customItemConf.GetSpecialItemName() != oldCustomItemConf.GetSpecialItemName() {
+		t.Fatal("CustomItemConf should be processed after reload")
+	}
+
+	if err := h.Reload("../testdata/conf/", format.JSON, []string{"NotExistConf"}); err == nil {
+		t.Fatal("expected error when reloading an unregistered messager")
+	}
+}
+
+// ProcessCountConf is a custom messager with loaded data, which counts its
+// ProcessAfterLoadAll calls.
+type ProcessCountConf struct {
+	loader.UnimplementedMessager
+	deps      []string
+	data      proto.Message
+	processed int
+}
+
+func (x *ProcessCountConf) Name() string {
+	return "ProcessCountConf"
+}
+
+func (x *ProcessCountConf) Load(dir string, format format.Format, opts *load.MessagerOptions) error {
+	x.data = &protoconf.ItemConf{}
+	return nil
+}
+
+func (x *ProcessCountConf) Message() proto.Message {
+	return x.data
+}
+
+func (x *ProcessCountConf) Dependencies() []string {
+	return x.deps
+}
+
+func (x *ProcessCountConf) ProcessAfterLoadAll(hub *loader.Hub) error {
+	x.processed++
+	return nil
+}
+
+func Test_Reload_ReusedUntouched(t *testing.T) {
+	for _, deps := range [][]string{nil, {"ItemConf"}} {
+		h := hub.NewMyHub(loader.WithMessager(func() loader.Messager { return &ProcessCountConf{deps: deps} }))
+		if err := h.Load("../testdata/conf/", format.JSON, load.IgnoreUnknownFields()); err != nil {
+			t.Fatalf("failed to load: %v", err)
+		}
+		old := h.GetMessager("ProcessCountConf").(*ProcessCountConf)
+		oldStats := *old.GetStats()
+		if err := h.Reload("../testdata/conf/", format.JSON, []string{"ItemConf"}, load.IgnoreUnknownFields()); err != nil {
+			t.Fatalf("failed to reload: %v", err)
+		}
+		if old.processed != 1 || !reflect.DeepEqual(*old.GetStats(), oldStats) {
+			t.Fatalf("reused ProcessCountConf (deps %v) should be untouched, processed %d times", deps, old.processed)
+		}
+		current := h.GetMessager("ProcessCountConf").(*ProcessCountConf)
+		if reused := current == old; reused != (deps == nil) {
+			t.Fatalf("ProcessCountConf (deps %v) reused: %v", deps, reused)
+		}
+		if current.processed != 1 {
+			t.Fatalf("ProcessCountConf (deps %v) processed %d times", deps, current.processed)
+		}
+	}
+}
+
+func Test_OnReload(t *testing.T) {
+	var (
+		calls   int
+		oldMC   *loader.MessagerContainer
+		newMC   *loader.MessagerContainer
+		changed []string
+	)
+	h := hub.NewMyHub(loader.WithOnReload(func(old, new *loader.MessagerContainer, names []string) {
+		calls++
+		oldMC, newMC, changed = old, new, names
+	}))
+	if err := h.Load("../testdata/conf/", format.JSON, load.IgnoreUnknownFields()); err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if calls != 1 || len(changed) != len(h.GetMessagerMap()) {
+		t.Fatalf("all messagers should be changed at first load, got: %v", changed)
+	}
+
+	// load again with no content changed
+	if err := h.Load("../testdata/conf/", format.JSON, load.IgnoreUnknownFields()); err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if calls != 2 || len(changed) != 0 {
+		t.Fatalf("no messagers should be changed, got: %v", changed)
+	}
+
+	// reload with patch
+	prev := h.FromContext(context.Background())
+	err := h.Reload("../testdata/conf/", format.JSON, []string{"PatchReplaceConf", "ItemConf"},
+		load.IgnoreUnknownFields(),
+		load.PatchDirs("../testdata/patchconf/"),
+	)
+	if err != nil {
+		t.Fatalf("failed to reload: %v", err)
+	}
+	if oldMC != prev || newMC != h.FromContext(context.Background()) {
+		t.Fatal("OnReload should be called with old and new containers")
+	}
+	if len(changed) != 1 || changed[0] != "PatchReplaceConf" {
+		t.Fatalf("only PatchReplaceConf should be changed, got: %v", changed)
+	}
+}
+
+func Test_LoadError(t *testing.T) {
+	dir := copyConfDir(t)
+	if err := os.Remove(filepath.Join(dir, "HeroConf.json")); err != nil {
+		t.Fatalf("failed to remove HeroConf.json: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "ItemConf.json"), []byte("{"), 0o644); err != nil {
+		t.Fatalf("failed to write ItemConf.json: %v", err)
+	}
+
+	// return on the first failure
+	err := hub.NewMyHub().Load(dir, format.JSON, load.IgnoreUnknownFields())
+	var loadErr *loader.LoadError
+	if !errors.As(err, &loadErr) {
+		t.Fatalf("expected LoadError, got: %v", err)
+	}
+	if loadErr.Messager != "HeroConf" || loadErr.Phase != loader.PhaseRead || loadErr.Path != filepath.Join(dir, "HeroConf.json") {
+		t.Fatalf("unexpected LoadError: %+v", loadErr)
+	}
+
+	// collect all failures
+	err = hub.NewMyHub(loader.WithCollectErrors()).Load(dir, format.JSON, load.IgnoreUnknownFields())
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("expected joined error, got: %v", err)
+	}
+	errs := joined.Unwrap()
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got: %v", errs)
+	}
+	if !errors.As(errs[1], &loadErr) {
+		t.Fatalf("expected LoadError, got: %v", errs[1])
+	}
+	if loadErr.Messager != "ItemConf" || loadErr.Phase != loader.PhaseParse || loadErr.Path != filepath.Join(dir, "ItemConf.json") {
+		t.Fatalf("unexpected LoadError: %+v", loadErr)
+	}
+}
+
+func Test_CheckRefer(t *testing.T) {
+	dir := copyConfDir(t)
+	path := filepath.Join(dir, "ActivityConf.json")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read ActivityConf.json: %v", err)
+	}
+	content = []byte(strings.Replace(string(content), `"chapterId": 1,`, `"chapterId": 1, "awardId": 999999,`, 1))
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		t.Fatalf("failed to write ActivityConf.json: %v", err)
+	}
+
+	err = hub.NewMyHub().Load(dir, format.JSON, load.IgnoreUnknownFields())
+	var loadErr *loader.LoadError
+	if !errors.As(err, &loadErr) {
+		t.Fatalf("expected LoadError, got: %v", err)
+	}
+	if loadErr.Messager != "ActivityConf" || loadErr.Phase != loader.PhaseCheckRefer {
+		t.Fatalf("unexpected LoadError: %+v", loadErr)
+	}
+	if !errors.Is(err, loader.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "activity_map[100001].chapter_map[1].award_id: ItemConf.ID(999999) not found") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// a negative value is out of range of the uint32 key, so it must not
+	// be looked up as a wrapped key
+	content = []byte(strings.Replace(string(content), `"costItemId": 2`, `"costItemId": -1`, 1))
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		t.Fatalf("failed to write ActivityConf.json: %v", err)
+	}
+	err = hub.NewMyHub().Load(dir, format.JSON, load.IgnoreUnknownFields())
+	if !strings.Contains(fmt.Sprint(err), "cost_item_id: ItemConf.ID(-1) not found") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// skip refer checks
+	err = hub.NewMyHub(loader.SkipReferChecks()).Load(dir, format.JSON, load.IgnoreUnknownFields())
+	if err != nil {
+		t.Fatalf("failed to load with refer checks skipped: %v", err)
+	}
+}
+
+func Test_CheckRefer_Order(t *testing.T) {
+	dir := copyConfDir(t)
+	path := filepath.Join(dir, "ActivityConf.json")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read ActivityConf.json: %v", err)
+	}
+	content = []byte(strings.ReplaceAll(string(content), `"chapterId": 1,`, `"chapterId": 1, "awardId": 999999,`))
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		t.Fatalf("failed to write ActivityConf.json: %v", err)
+	}
+
+	// dangling references are reported in sorted key order
+	var first string
+	for i := 0; i < 5; i++ {
+		err := hub.NewMyHub().Load(dir, format.JSON, load.IgnoreUnknownFields())
+		if err == nil {
+			t.Fatal("expected refer check error, got nil")
+		}
+		if i == 0 {
+			first = err.Error()
+		} else if err.Error() != first {
+			t.Fatalf("unstable error:\n got:  %v\n want: %v", err, first)
+		}
+	}
+	lines := strings.Split(first, "\n")
+	if len(lines) < 3 {
+		t.Fatalf("expected multiple dangling references, got: %v", first)
+	}
+	if !slices.IsSorted(lines[1:]) {
+		t.Fatalf("dangling references not sorted: %v", first)
+	}
+}
+
+func Test_Rollback(t *testing.T) {
+	h := hub.NewMyHub(loader.WithKeepGenerations(2))
+	if h.Generation() != 0 {
+		t.Fatalf("expected generation 0 before loading, got %d", h.Generation())
+	}
+	var itemConfs []*loader.ItemConf
+	for i := 0; i < 4; i++ {
+		if err := h.Load("../testdata/conf/", format.JSON, load.IgnoreUnknownFields()); err != nil {
+			t.Fatalf("failed to load: %v", err)
+		}
+		itemConfs = append(itemConfs, h.GetItemConf())
+	}
+	if h.Generation() != 4 {
+		t.Fatalf("expected generation 4, got %d", h.Generation())
+	}
+	generations := h.GetGenerations()
+	if len(generations) != 2 || generations[0].ID != 2 || generations[1].ID != 3 {
+		t.Fatalf("unexpected generations: %+v", generations)
+	}
+
+	if err := h.Rollback(); err != nil {
+		t.Fatalf("failed to rollback: %v", err)
+	}
+	if h.Generation() != 3 || h.GetItemConf() != itemConfs[2] {
+		t.Fatalf("expected rolled back to generation 3, got %d", h.Generation())
+	}
+	if err := h.Rollback(); err != nil {
+		t.Fatalf("failed to rollback: %v", err)
+	}
+	if h.Generation() != 2 || h.GetItemConf() != itemConfs[1] {
+		t.Fatalf("expected rolled back to generation 2, got %d", h.Generation())
+	}
+	if err := h.Rollback(); !errors.Is(err, loader.ErrNoGeneration) {
+		t.Fatalf("expected ErrNoGeneration, got: %v", err)
+	}
+
+	// generations keep increasing after rollback
+	if err := h.Load("../testdata/conf/", format.JSON, load.IgnoreUnknownFields()); err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if h.Generation() != 5 {
+		t.Fatalf("expected generation 5, got %d", h.Generation())
+	}
+}
+
+func Test_LoadFS(t *testing.T) {
+	h := hub.NewMyHub()
+	err := h.LoadFS(os.DirFS("../testdata"), "conf", format.JSON,
+		load.IgnoreUnknownFields(),
+		load.PatchDirs("patchconf"),
+	)
+	if err != nil {
+		t.Fatalf("failed to load from fs: %v", err)
+	}
+	expected := hub.NewMyHub()
+	err = expected.Load("../testdata/conf/", format.JSON,
+		load.IgnoreUnknownFields(),
+		load.PatchDirs("../testdata/patchconf/"),
+	)
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	for name, msger := range expected.GetMessagerMap() {
+		if !proto.Equal(h.GetMessager(name).Message(), msger.Message()) {
+			t.Fatalf("%s loaded from fs differs", name)
+		}
+	}
+
+	// per-messager path resolved inside fs
+	content, err := os.ReadFile("../testdata/conf/ItemConf.json")
+	if err != nil {
+		t.Fatalf("failed to read ItemConf.json: %v", err)
+	}
+	fsys := fstest.MapFS{"items/ItemConf.json": {Data: content}}
+	h = hub.NewMyHub(loader.Filter(func(name string) bool { return name == "ItemConf" }))
+	err = h.LoadFS(fsys, ".", format.JSON,
+		load.IgnoreUnknownFields(),
+		load.WithMessagerOptions(map[string]*load.MessagerOptions{
+			"ItemConf": {
+				Path: "items/ItemConf.json",
+			},
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to load from fs: %v", err)
+	}
+	if !proto.Equal(h.GetItemConf().Data(), expected.GetItemConf().Data()) {
+		t.Fatal("ItemConf loaded from fs differs")
+	}
+
+	// missing file
+	err = h.LoadFS(fstest.MapFS{}, ".", format.JSON)
+	var loadErr *loader.LoadError
+	if !errors.As(err, &loadErr) || loadErr.Phase != loader.PhaseRead || loadErr.Path != "ItemConf.json" {
+		t.Fatalf("expected read LoadError, got: %v", err)
+	}
+}
+
+func Test_Stats(t *testing.T) {
+	h := hub.NewMyHub()
+	err := h.Load("../testdata/conf/", format.JSON,
+		load.IgnoreUnknownFields(),
+		load.PatchDirs("../testdata/patchconf/"),
+	)
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	conf := h.GetPatchMergeConf()
+	stats := conf.GetStats()
+	mainPath := filepath.Join("../testdata/conf/", "PatchMergeConf.json")
+	patchPath := filepath.Join("../testdata/patchconf/", "PatchMergeConf.json")
+	if !slices.Equal(stats.Paths, []string{mainPath, patchPath}) {
+		t.Fatalf("unexpected paths: %v", stats.Paths)
+	}
+	if !slices.Equal(stats.PatchPaths, []string{patchPath}) {
+		t.Fatalf("unexpected patch paths: %v", stats.PatchPaths)
+	}
+	mainInfo, err := os.Stat(mainPath)
+	if err != nil {
+		t.Fatalf("failed to stat %s: %v", mainPath, err)
+	}
+	patchInfo, err := os.Stat(patchPath)
+	if err != nil {
+		t.Fatalf("failed to stat %s: %v", patchPath, err)
+	}
+	if stats.ByteSize != int(mainInfo.Size()+patchInfo.Size()) {
+		t.Fatalf("unexpected byte size: %d", stats.ByteSize)
+	}
+	data := conf.Data()
+	if stats.EntryCount != len(data.GetPriceList())+len(data.GetReplacePriceList())+len(data.GetItemMap())+len(data.GetReplaceItemMap()) {
+		t.Fatalf("unexpected entry count: %d", stats.EntryCount)
+	}
+	if stats.ReadDuration <= 0 || stats.UnmarshalDuration <= 0 || stats.PatchDuration <= 0 {
+		t.Fatalf("unexpected durations: %+v", stats)
+	}
+	if stats.Duration < stats.ReadDuration+stats.UnmarshalDuration+stats.PatchDuration+stats.ProcessAfterLoadDuration {
+		t.Fatalf("total duration should cover all phases: %+v", stats)
+	}
+
+	stats = h.GetItemConf().GetStats()
+	if len(stats.Paths) != 1 || len(stats.PatchPaths) != 0 || stats.PatchDuration != 0 || stats.EntryCount != len(h.GetItemConf().Data().GetItemMap()) {
+		t.Fatalf("unexpected stats of ItemConf: %+v", stats)
+	}
+	if stats.ProcessAfterLoadDuration <= 0 {
+		t.Fatalf("ItemConf should take time to build ordered maps and indexes: %+v", stats)
+	}
+}
+
+func Test_MessagerLoadContext(t *testing.T) {
+	conf := &loader.ItemConf{}
+	if err := conf.LoadContext(context.Background(), "../testdata/conf/", format.JSON, nil); err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	data, stats := conf.Data(), *conf.GetStats()
+	if len(data.GetItemMap()) == 0 || len(stats.Paths) != 1 {
+		t.Fatalf("unexpected data or stats: %v, %+v", data, stats)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	reading, release := make(chan struct{}), make(chan struct{})
+	opts := &load.MessagerOptions{
+		BaseOptions: load.BaseOptions{
+			ReadFunc: func(name string) ([]byte, error) {
+				close(reading)
+				<-release
+				return os.ReadFile(name)
+			},
+		},
+	}
+	go func() {
+		<-reading
+		cancel()
+	}()
+	err := conf.LoadContext(ctx, "../testdata/conf/", format.JSON, opts)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got: %v", err)
+	}
+	// the abandoned load finishes into a new message
+	close(release)
+	time.Sleep(50 * time.Millisecond)
+	if conf.Data() != data || !reflect.DeepEqual(conf.GetStats().Paths, stats.Paths) {
+		t.Fatal("messager should be left untouched if the load is aborted")
+	}
+}
+
+func Test_LoadContext(t *testing.T) {
+	h := prepareHub(t)
+	generation := h.Generation()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := h.LoadContext(ctx, "../testdata/conf/", format.JSON, load.IgnoreUnknownFields())
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got: %v", err)
+	}
+	if h.Generation() != generation {
+		t.Fatal("container should be untouched on canceled load")
+	}
+
+	// block reading ItemConf until the test ends
+	unblock := make(chan struct{})
+	defer close(unblock)
+	readFunc := func(path string) ([]byte, error) {
+		if filepath.Base(path) == "ItemConf.json" {
+			<-unblock
+		}
+		return os.ReadFile(path)
+	}
+	observer := &statsObserver{stats: map[string]*loader.Stats{}}
+	h = hub.NewMyHub(loader.WithMessagerLoadTimeout("ItemConf", 50*time.Millisecond), loader.WithLoadObserver(observer))
+	err = h.Load("../testdata/conf/", format.JSON, load.IgnoreUnknownFields(), load.WithReadFunc(readFunc))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got: %v", err)
+	}
+	var loadErr *loader.LoadError
+	if !errors.As(err, &loadErr) || loadErr.Messager != "ItemConf" {
+		t.Fatalf("expected LoadError of ItemConf, got: %v", err)
+	}
+	if len(h.GetMessagerMap()) != 0 {
+		t.Fatal("container should be untouched on load timeout")
+	}
+	// no stats of the aborted load, which may be still filling them
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+	if stats, ok := observer.stats["ItemConf"]; !ok || stats != nil {
+		t.Fatalf("expected nil stats of aborted ItemConf, got: %v", stats)
+	}
+}
+
+// statsObserver records the stats passed to OnMessagerEnd.
+type statsObserver struct {
+	loader.UnimplementedLoadObserver
+	mu    sync.Mutex
+	stats map[string]*loader.Stats
+}
+
+func (o *statsObserver) OnMessagerEnd(ctx context.Context, name string, stats *loader.Stats, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.stats[name] = stats
+}
+
+// LoadTestConf is a custom messager which overrides only Load.
+type LoadTestConf struct {
+	loader.UnimplementedMessager
+	dir string
+}
+
+func (x *LoadTestConf) Name() string {
+	return "LoadTestConf"
+}
+
+func (x *LoadTestConf) Load(dir string, format format.Format, opts *load.MessagerOptions) error {
+	x.dir = dir
+	return nil
+}
+
+func Test_CustomMessager_Load(t *testing.T) {
+	h := hub.NewMyHub(loader.WithMessager(func() loader.Messager { return new(LoadTestConf) }))
+	if err := h.Load("../testdata/conf/", format.JSON, load.IgnoreUnknownFields()); err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if dir := h.GetMessager("LoadTestConf").(*LoadTestConf).dir; dir != "../testdata/conf/" {
+		t.Fatalf("custom Load not called, got dir: %q", dir)
+	}
+}
+
+func Test_CustomMessager_LoadFS(t *testing.T) {
+	// LoadTestConf does not override LoadFS, so it loads nothing
+	h := hub.NewMyHub(loader.WithMessager(func() loader.Messager { return new(LoadTestConf) }))
+	if err := h.LoadFS(os.DirFS("../testdata/conf"), ".", format.JSON, load.IgnoreUnknownFields()); err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if dir := h.GetMessager("LoadTestConf").(*LoadTestConf).dir; dir != "" {
+		t.Fatalf("custom Load should not be called, got dir: %q", dir)
+	}
+}
+
+func Test_Optional(t *testing.T) {
+	dir := copyConfDir(t)
+	for _, name := range []string{"ThemeConf.json", "StrcaseConf.json"} {
+		if err := os.Remove(filepath.Join(dir, name)); err != nil {
+			t.Fatalf("failed to remove %s: %v", name, err)
+		}
+	}
+
+	// StrcaseConf is not optional
+	h := hub.NewMyHub()
+	err := h.Load(dir, format.JSON, load.IgnoreUnknownFields())
+	var loadErr *loader.LoadError
+	if !errors.As(err, &loadErr) || loadErr.Messager != "StrcaseConf" || loadErr.Phase != loader.PhaseRead {
+		t.Fatalf("expected read error of StrcaseConf, got: %v", err)
+	}
+
+	// ThemeConf is optional by worksheet label, and StrcaseConf by option
+	h = hub.NewMyHub(loader.WithOptional(func(name string) bool { return name == "StrcaseConf" }))
+	if err := h.Load(dir, format.JSON, load.IgnoreUnknownFields()); err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if h.GetThemeConf() != nil || h.GetStrcaseConf() != nil {
+		t.Fatal("missing optional messagers should be nil")
+	}
+	if h.GetItemConf() == nil {
+		t.Fatal("ItemConf should be loaded")
+	}
+	want := []loader.MissingFile{
+		{Messager: "StrcaseConf", Path: filepath.Join(dir, "StrcaseConf.json")},
+		{Messager: "ThemeConf", Path: filepath.Join(dir, "ThemeConf.json")},
+	}
+	if got := h.GetMissingFiles(); !slices.Equal(got, want) {
+		t.Fatalf("missing files: got %v, want %v", got, want)
+	}
+}
+
+func Test_LoadProfile(t *testing.T) {
+	const testdataDir = "../testdata"
+	h := hub.NewMyHub(
+		loader.WithProfile("prod", &loader.Profile{PatchDirs: []string{testdataDir + "/patchconf/"}}),
+		loader.WithProfile("cn-prod", &loader.Profile{
+			Base:      "prod",
+			PatchDirs: []string{testdataDir + "/patchconf2/"},
+			MessagerOptions: map[string]*load.MessagerOptions{
+				"PatchMergeConf": {PatchPaths: []string{testdataDir + "/patchconf2/PatchMergeConf.txtpb"}},
+			},
+		}),
+		loader.WithProfile("loop-a", &loader.Profile{Base: "loop-b"}),
+		loader.WithProfile("loop-b", &loader.Profile{Base: "loop-a"}),
+	)
+	if err := h.LoadProfile(testdataDir+"/conf/", format.JSON, "cn-prod", load.IgnoreUnknownFields()); err != nil {
+		t.Fatalf("failed to load profile: %v", err)
+	}
+	if h.GetProfile() != "cn-prod" {
+		t.Fatalf("unexpected profile: %q", h.GetProfile())
+	}
+
+	expected := hub.NewMyHub()
+	err := expected.Load(testdataDir+"/conf/", format.JSON,
+		load.IgnoreUnknownFields(),
+		load.PatchDirs(testdataDir+"/patchconf/", testdataDir+"/patchconf2/"),
+		load.WithMessagerOptions(map[string]*load.MessagerOptions{
+			"PatchMergeConf": {PatchPaths: []string{testdataDir + "/patchconf2/PatchMergeConf.txtpb"}},
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	for name, msger := range expected.GetMessagerMap() {
+		if !proto.Equal(msger.Message(), h.GetMessager(name).Message()) {
+			t.Errorf("messager %s mismatch:\n got:      %v\n expected: %v", name, h.GetMessager(name).Message(), msger.Message())
+		}
+	}
+	if got := h.GetPatchMergeConf().GetStats().PatchPaths; !slices.Equal(got, []string{testdataDir + "/patchconf2/PatchMergeConf.txtpb"}) {
+		t.Errorf("unexpected patch paths of PatchMergeConf: %v", got)
+	}
+
+	// reload keeps the profile
+	if err := h.Reload(testdataDir+"/conf/", format.JSON, []string{"PatchMergeConf", "RecursivePatchConf"}, load.IgnoreUnknownFields()); err != nil {
+		t.Fatalf("failed to reload: %v", err)
+	}
+	if h.GetProfile() != "cn-prod" {
+		t.Fatalf("profile should be kept by reload, got %q", h.GetProfile())
+	}
+	for _, name := range []string{"PatchMergeConf", "RecursivePatchConf"} {
+		if !proto.Equal(expected.GetMessager(name).Message(), h.GetMessager(name).Message()) {
+			t.Errorf("reloaded messager %s mismatch:\n got:      %v\n expected: %v", name, h.GetMessager(name).Message(), expected.GetMessager(name).Message())
+		}
+	}
+
+	if err := h.Load(testdataDir+"/conf/", format.JSON, load.IgnoreUnknownFields()); err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if h.GetProfile() != "" {
+		t.Fatalf("profile should be cleared, got %q", h.GetProfile())
+	}
+	if err := h.LoadProfile(testdataDir+"/conf/", format.JSON, "not-exist"); !errors.Is(err, loader.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got: %v", err)
+	}
+	if err := h.LoadProfile(testdataDir+"/conf/", format.JSON, "loop-a"); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Fatalf("expected profile cycle error, got: %v", err)
+	}
+}
+
+func Test_Logger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	h := hub.NewMyHub(loader.WithLogger(logger))
+	err := h.Load("../testdata/conf/", format.JSON,
+		load.IgnoreUnknownFields(),
+		load.PatchDirs("../testdata/patchconf/"),
+	)
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if err := h.Load("../testdata/not-exist/", format.JSON); err == nil {
+		t.Fatal("expected error when loading from non-existent dir")
+	}
+
+	var events []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var event map[string]any
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("failed to unmarshal log line %q: %v", line, err)
+		}
+		events = append(events, event)
+	}
+	hasEvent := func(msg string, attrs map[string]any) bool {
+		return slices.ContainsFunc(events, func(event map[string]any) bool {
+			if event["msg"] != msg {
+				return false
+			}
+			for key, value := range attrs {
+				if event[key] != value {
+					return false
+				}
+			}
+			return true
+		})
+	}
+	for _, want := range []struct {
+		msg   string
+		attrs map[string]any
+	}{
+		{"load started", map[string]any{loader.LogKeyDir: "../testdata/conf/"}},
+		{"messager loaded", map[string]any{loader.LogKeyMessager: "ItemConf"}},
+		{"patch applied", map[string]any{loader.LogKeyMessager: "PatchMergeConf"}},
+		{"load finished", map[string]any{loader.LogKeyDir: "../testdata/conf/"}},
+		{"container set", map[string]any{loader.LogKeyGeneration: float64(1)}},
+		{"load failed", map[string]any{loader.LogKeyDir: "../testdata/not-exist/"}},
+	} {
+		if !hasEvent(want.msg, want.attrs) {
+			t.Errorf("event %q with %v not logged", want.msg, want.attrs)
+		}
+	}
+}
+
+type recordingObserver struct {
+	loader.UnimplementedLoadObserver
+	mu     sync.Mutex
+	events []string
+}
+
+func (o *recordingObserver) record(event string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = append(o.events, event)
+}
+
+// spanKey is the context key of the span derived by recordingObserver.
+type spanKey struct{}
+
+func (o *recordingObserver) OnLoadStart(ctx context.Context, names []string) context.Context {
+	o.record("LoadStart:" + strings.Join(names, ","))
+	return context.WithValue(ctx, spanKey{}, "load")
+}
+
+func (o *recordingObserver) OnMessagerStart(ctx context.Context, name string) context.Context {
+	if ctx.Value(spanKey{}) != "load" {
+		o.record("MessagerStart:" + name + ":orphan")
+	}
+	return context.WithValue(ctx, spanKey{}, name)
+}
+
+func (o *recordingObserver) OnMessagerEnd(ctx context.Context, name string, stats *loader.Stats, err error) {
+	if ctx.Value(spanKey{}) != name {
+		o.record("MessagerEnd:" + name + ":orphan")
+	}
+	o.record(fmt.Sprintf("MessagerEnd:%s:%v", name, err != nil))
+}
+
+func (o *recordingObserver) OnPostProcess(ctx context.Context, duration time.Duration, err error) {
+	o.record(fmt.Sprintf("PostProcess:%s:%v", ctx.Value(spanKey{}), err != nil))
+}
+
+func (o *recordingObserver) OnLoadEnd(ctx context.Context, duration time.Duration, err error) {
+	o.record(fmt.Sprintf("LoadEnd:%s:%v", ctx.Value(spanKey{}), err != nil))
+}
+
+func (o *recordingObserver) OnCommit(generation uint64) {
+	o.record(fmt.Sprintf("Commit:%d", generation))
+}
+
+func Test_LoadObserver(t *testing.T) {
+	observer := &recordingObserver{}
+	h := hub.NewMyHub(loader.WithLoadObserver(observer), loader.WithKeepGenerations(1))
+	if err := h.Load("../testdata/conf/", format.JSON, load.IgnoreUnknownFields()); err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	names := slices.Sorted(maps.Keys(h.GetMessagerMap()))
+	want := []string{"LoadStart:" + strings.Join(names, ",")}
+	for _, name := range names {
+		want = append(want, "MessagerEnd:"+name+":false")
+	}
+	want = append(want, "PostProcess:load:false", "LoadEnd:load:false", "Commit:1")
+	if !slices.Equal(observer.events, want) {
+		t.Fatalf("events:\n got:  %v\n want: %v", observer.events, want)
+	}
+
+	observer.events = nil
+	if err := h.Reload("../testdata/conf/", format.JSON, []string{"ItemConf"}, load.IgnoreUnknownFields()); err != nil {
+		t.Fatalf("failed to reload: %v", err)
+	}
+	// custom messagers without loaded data are always loaded anew
+	start := observer.events[0]
+	if !strings.Contains(start, "ItemConf") || strings.Contains(start, "HeroConf") {
+		t.Fatalf("unexpected load start event: %s", start)
+	}
+	want = []string{"MessagerEnd:ItemConf:false", "PostProcess:load:false", "LoadEnd:load:false", "Commit:2"}
+	if got := observer.events; !slices.Contains(got, want[0]) || !slices.Equal(got[len(got)-3:], want[1:]) {
+		t.Fatalf("events:\n got:  %v\n want: %v", got, want)
+	}
+
+	observer.events = nil
+	if err := h.Reload("../testdata/not-exist/", format.JSON, []string{"ItemConf"}); err == nil {
+		t.Fatal("expected error when reloading from non-existent dir")
+	}
+	if got := observer.events; !slices.Contains(got, "MessagerEnd:ItemConf:true") || got[len(got)-1] != "LoadEnd:load:true" {
+		t.Fatalf("unexpected events on failure: %v", got)
+	}
+
+	observer.events = nil
+	if err := h.Rollback(); err != nil {
+		t.Fatalf("failed to roll back: %v", err)
+	}
+	if want := []string{"Commit:1"}; !slices.Equal(observer.events, want) {
+		t.Fatalf("events on rollback:\n got:  %v\n want: %v", observer.events, want)
+	}
+}
+
+func Test_CheckMutations(t *testing.T) {
+	var mu sync.Mutex
+	var mutated []string
+	h := hub.NewMyHub(loader.WithMutableCheck(&loader.MutableCheck{
+		Interval: 10 * time.Millisecond,
+		Pacing:   -1,
+		OnMutate: func(name string, original, current proto.Message) {
+			mu.Lock()
+			defer mu.Unlock()
+			mutated = append(mutated, name)
+		},
+	}))
+	defer h.Close()
+	if err := h.Load("../testdata/conf/", format.JSON, load.IgnoreUnknownFields()); err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if reports := h.CheckMutations(); len(reports) != 0 {
+		t.Fatalf("unexpected mutations: %v", reports)
+	}
+
+	// mutate before committing, as mutations are racy with the mutable check
+	pending, err := h.Prepare("../testdata/conf/", format.JSON, load.IgnoreUnknownFields())
+	if err != nil {
+		t.Fatalf("failed to prepare: %v", err)
+	}
+	itemMap := pending.Container().GetItemConf().Data().GetItemMap()
+	itemMap[1].Name = "mutated"
+	delete(itemMap, 2)
+	if err := pending.Commit(); err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+	reports := h.CheckMutations()
+	if len(reports) != 1 || reports[0].Messager != "ItemConf" {
+		t.Fatalf("unexpected mutations: %v", reports)
+	}
+	if name := reports[0].Original.(*protoconf.ItemConf).GetItemMap()[1].GetName(); name != "apple" {
+		t.Fatalf("unexpected original name: %s", name)
+	}
+	if keys := reports[0].Keys; !slices.Equal(keys, []string{"item_map[1]", "item_map[2]"}) {
+		t.Fatalf("unexpected mutated keys: %v", keys)
+	}
+	changes := reports[0].Changes
+	if len(changes) != 2 {
+		t.Fatalf("unexpected changes: %v", changes)
+	}
+	if want := (loader.FieldChange{Path: "item_map[1].name", Old: "apple", New: "mutated"}); changes[0] != want {
+		t.Fatalf("unexpected change: got %v, want %v", changes[0], want)
+	}
+	if changes[1].Path != "item_map[2]" || changes[1].Old == nil || changes[1].New != nil {
+		t.Fatalf("unexpected change: %v", changes[1])
+	}
+	if text := reports[0].String(); !strings.Contains(text, `item_map[1].name: "apple" -> "mutated"`) {
+		t.Fatalf("unexpected report text: %s", text)
+	}
+	waitFor(t, "mutation handled", func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Contains(mutated, "ItemConf")
+	})
+
+	// mutations of unknown fields are not reported
+	pending, err = h.Prepare("../testdata/conf/", format.JSON, load.IgnoreUnknownFields())
+	if err != nil {
+		t.Fatalf("failed to prepare: %v", err)
+	}
+	pending.Container().GetItemConf().Data().ProtoReflect().SetUnknown(protowire.AppendVarint(protowire.AppendTag(nil, 1000, protowire.VarintType), 1))
+	if err := pending.Commit(); err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+	if reports := h.CheckMutations(); len(reports) != 0 {
+		t.Fatalf("unexpected mutations: %v", reports)
+	}
+
+	if err := h.Close(); err != nil {
+		t.Fatalf("failed to close: %v", err)
+	}
+	mu.Lock()
+	mutated = nil
+	mu.Unlock()
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	if len(mutated) != 0 {
+		t.Fatalf("mutable check should be stopped after closed, got: %v", mutated)
+	}
+}
+
+func Test_PreparePatch(t *testing.T) {
+	h := prepareHub(t)
+	patch := []byte(`[
+		{"op":"replace","path":"/itemMap/1/name","value":"pear"},
+		{"op":"add","path":"/itemMap/100","value":{"id":100,"name":"plum","type":"FRUIT_TYPE_APPLE"}}
+	]`)
+	pending, err := h.PreparePatch("ItemConf", patch, udiff.ApplyJSONPatch)
+	if err != nil {
+		t.Fatalf("failed to patch ItemConf: %v", err)
+	}
+	conf := pending.Container().GetItemConf()
+	if item, err := conf.Get1(1); err != nil || item.GetName() != "pear" {
+		t.Fatalf("Get1(1) = %v, %v, want name pear", item, err)
+	}
+	if item, err := conf.Get1(100); err != nil || item.GetName() != "plum" {
+		t.Fatalf("Get1(100) = %v, %v, want name plum", item, err)
+	}
+	// indexes are built from the patched message
+	if !slices.ContainsFunc(conf.FindItem(protoconf.FruitType_FRUIT_TYPE_APPLE), func(item *protoconf.ItemConf_Item) bool {
+		return item.GetId() == 100
+	}) {
+		t.Fatal("FindItem(FRUIT_TYPE_APPLE) does not contain the added item 100")
+	}
+	// no file is read for the patched messager
+	if stats := conf.GetStats(); len(stats.Paths) != 0 || stats.EntryCount != len(conf.Data().GetItemMap()) {
+		t.Fatalf("unexpected stats of patched ItemConf: %+v", stats)
+	}
+	// ProcessAfterLoadAll is run on messagers depending on the patched one
+	if name := pending.Container().GetMessager(customconf.CustomItemConfName).(*customconf.CustomItemConf).GetSpecialItemName(); name != "pear" {
+		t.Fatalf("special item name = %s, want pear", name)
+	}
+	// the other messagers are reused
+	if pending.Container().GetHeroConf() != h.GetHeroConf() {
+		t.Fatal("HeroConf should be reused")
+	}
+	// the current messagers are left untouched until committed
+	if item, _ := h.GetItemConf().Get1(1); item.GetName() != "apple" {
+		t.Fatalf("current Get1(1) name = %s, want apple", item.GetName())
+	}
+	if name := h.GetCustomItemConf().GetSpecialItemName(); name != "apple" {
+		t.Fatalf("current special item name = %s, want apple", name)
+	}
+
+	// a delta computed between two versions applies back
+	mergePatch, err := udiff.MergePatch(h.GetItemConf().Data(), conf.Data())
+	if err != nil {
+		t.Fatalf("failed to compute merge patch: %v", err)
+	}
+	merged, err := h.PreparePatch("ItemConf", mergePatch, udiff.ApplyMergePatch)
+	if err != nil {
+		t.Fatalf("failed to merge patch ItemConf: %v", err)
+	}
+	if !proto.Equal(conf.Data(), merged.Container().GetItemConf().Data()) {
+		t.Fatal("merge patched ItemConf differs from JSON patched one")
+	}
+	merged.Discard()
+	generation := h.Generation()
+	if err := pending.Commit(); err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+	if h.Generation() != generation+1 || h.GetItemConf() != conf {
+		t.Fatal("patched container should take effect after committed")
+	}
+
+	if _, err := h.PreparePatch("NotExistConf", patch, udiff.ApplyJSONPatch); !errors.Is(err, loader.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got: %v", err)
+	}
+	if _, err := h.PreparePatch("ItemConf", []byte(`[{"op":"remove","path":"/notExist"}]`), udiff.ApplyJSONPatch); err == nil {
+		t.Fatal("expected error of invalid patch, got nil")
+	}
+	// CustomItemConf fails in ProcessAfterLoadAll without item 1
+	_, err = h.PreparePatch("ItemConf", []byte(`[{"op":"remove","path":"/itemMap/1"}]`), udiff.ApplyJSONPatch)
+	var loadErr *loader.LoadError
+	if !errors.As(err, &loadErr) || loadErr.Messager != "CustomItemConf" || loadErr.Phase != loader.PhaseProcessAfterLoadAll {
+		t.Fatalf("expected ProcessAfterLoadAll error of CustomItemConf, got: %v", err)
+	}
+}
+
+func Test_PreparePatch_Instrumentation(t *testing.T) {
+	var buf bytes.Buffer
+	observer := &recordingObserver{}
+	h := hub.NewMyHub(loader.WithLoadObserver(observer), loader.WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))))
+	if err := h.Load("../testdata/conf/", format.JSON, load.IgnoreUnknownFields()); err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+
+	observer.events = nil
+	patch := []byte(`[{"op":"replace","path":"/itemMap/1/name","value":"pear"}]`)
+	pending, err := h.PreparePatch("ItemConf", patch, udiff.ApplyJSONPatch)
+	if err != nil {
+		t.Fatalf("failed to patch ItemConf: %v", err)
+	}
+	pending.Discard()
+	start := observer.events[0]
+	if !strings.HasPrefix(start, "LoadStart:") || !strings.Contains(start, "ItemConf") || strings.Contains(start, "HeroConf") {
+		t.Fatalf("unexpected load start event: %s", start)
+	}
+	want := []string{"MessagerEnd:ItemConf:false", "PostProcess:load:false", "LoadEnd:load:false"}
+	if got := observer.events; !slices.Contains(got, want[0]) || !slices.Equal(got[len(got)-2:], want[1:]) {
+		t.Fatalf("events:\n got:  %v\n want: %v", got, want)
+	}
+
+	// a failed patch is observed, counted and logged
+	observer.events = nil
+	failures := h.GetMetrics().LoadFailures
+	if _, err := h.PreparePatch("ItemConf", []byte(`[{"op":"remove","path":"/notExist"}]`), udiff.ApplyJSONPatch); err == nil {
+		t.Fatal("expected error of invalid patch, got nil")
+	}
+	if got := observer.events; len(got) == 0 || got[len(got)-1] != "LoadEnd:load:true" {
+		t.Fatalf("unexpected events of failed patch: %v", got)
+	}
+	if got := h.GetMetrics().LoadFailures; got != failures+1 {
+		t.Fatalf("LoadFailures = %d, want %d", got, failures+1)
+	}
+	if !strings.Contains(buf.String(), `"msg":"load failed","messager":"ItemConf"`) {
+		t.Fatalf("failed patch not logged: %s", buf.String())
+	}
+}
+
+func Test_Close_ZeroHub(t *testing.T) {
+	var h loader.Hub
+	if err := h.Close(); err != nil {
+		t.Fatalf("failed to close zero hub: %v", err)
+	}
+}
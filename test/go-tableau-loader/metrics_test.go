@@ -0,0 +1,97 @@
+package loader_test
+
+import (
+	"encoding/json"
+	"expvar"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tableauio/loader/test/go-tableau-loader/hub"
+	"github.com/tableauio/loader/test/go-tableau-loader/protoconf/loader"
+	"github.com/tableauio/tableau/format"
+	"github.com/tableauio/tableau/load"
+	"google.golang.org/protobuf/proto"
+)
+
+func Test_Metrics(t *testing.T) {
+	h := hub.NewMyHub()
+	if metrics := h.GetMetrics(); metrics.LoadSuccesses != 0 || !metrics.LastSuccessTime.IsZero() {
+		t.Fatalf("unexpected metrics before loading: %+v", metrics)
+	}
+	if err := h.Load("../testdata/conf/", format.JSON, load.IgnoreUnknownFields()); err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if err := h.Load("../testdata/not-exist/", format.JSON); err == nil {
+		t.Fatal("expected error when loading from non-existent dir")
+	}
+	metrics := h.GetMetrics()
+	if metrics.LoadSuccesses != 1 || metrics.LoadFailures != 1 || metrics.LastSuccessTime.IsZero() {
+		t.Fatalf("unexpected metrics: %+v", metrics)
+	}
+	var itemConf *loader.MessagerMetrics
+	for i := range metrics.Messagers {
+		if metrics.Messagers[i].Name == "ItemConf" {
+			itemConf = &metrics.Messagers[i]
+		}
+	}
+	if itemConf == nil {
+		t.Fatal("metrics of ItemConf not found")
+	}
+	data := h.GetItemConf().Data()
+	if itemConf.ByteSize != proto.Size(data) || itemConf.RecordCount != len(data.GetItemMap()) {
+		t.Fatalf("unexpected metrics of ItemConf: %+v", itemConf)
+	}
+
+	h.PublishExpvar("Test_Metrics")
+	var published loader.Metrics
+	if err := json.Unmarshal([]byte(expvar.Get("Test_Metrics").String()), &published); err != nil {
+		t.Fatalf("failed to unmarshal expvar: %v", err)
+	}
+	if published.LoadSuccesses != 1 || len(published.Messagers) != len(metrics.Messagers) {
+		t.Fatalf("unexpected published metrics: %+v", published)
+	}
+
+	rec := httptest.NewRecorder()
+	h.MetricsHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+	text := string(body)
+	for _, want := range []string{
+		"tableau_loader_load_successes_total 1\n",
+		"tableau_loader_load_failures_total 1\n",
+		"tableau_loader_rollbacks_total 0\n",
+		"tableau_loader_seconds_since_last_success ",
+		`tableau_loader_messager_records{messager="ItemConf"} `,
+		`tableau_loader_messager_bytes{messager="ItemConf"} `,
+		`tableau_loader_messager_load_duration_seconds{messager="ItemConf"} `,
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("metrics text missing %q:\n%s", want, text)
+		}
+	}
+	if !strings.HasSuffix(text, "# EOF\n") {
+		t.Fatalf("metrics text should end with # EOF:\n%s", text)
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/openmetrics-text") {
+		t.Fatalf("unexpected content type: %s", ct)
+	}
+}
+
+func Test_Metrics_SetAndRollback(t *testing.T) {
+	h := hub.NewMyHub(loader.WithKeepGenerations(1))
+	if err := h.Load("../testdata/conf/", format.JSON, load.IgnoreUnknownFields()); err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	// a manually set container is not a load success
+	h.SetMessagerMap(h.GetMessagerMap())
+	if metrics := h.GetMetrics(); metrics.LoadSuccesses != 1 || metrics.Rollbacks != 0 {
+		t.Fatalf("unexpected metrics after SetMessagerMap: %+v", metrics)
+	}
+	if err := h.Rollback(); err != nil {
+		t.Fatalf("failed to roll back: %v", err)
+	}
+	if metrics := h.GetMetrics(); metrics.LoadSuccesses != 1 || metrics.Rollbacks != 1 {
+		t.Fatalf("unexpected metrics after Rollback: %+v", metrics)
+	}
+}
@@ -7,6 +7,7 @@
 package loader
 
 import (
+	context "context"
 	fmt "fmt"
 	pair "github.com/tableauio/loader/pkg/pair"
 	treemap "github.com/tableauio/loader/pkg/treemap"
@@ -16,6 +17,7 @@ import (
 	load "github.com/tableauio/tableau/load"
 	store "github.com/tableauio/tableau/store"
 	proto "google.golang.org/protobuf/proto"
+	fs "io/fs"
 	time "time"
 )
 
@@ -56,15 +58,69 @@ func (x *HeroConf) Load(dir string, format format.Format, opts *load.MessagerOpt
 	defer func() {
 		x.Stats.Duration = time.Since(start)
 	}()
+	x.Stats = Stats{}
 	x.data = &protoconf.HeroConf{}
-	err := load.LoadMessagerInDir(x.data, dir, format, opts)
+	err := loadMessagerInDir(x.data, dir, format, opts, &x.Stats)
 	if err != nil {
 		return err
 	}
+	return x.afterLoad()
+}
+
+// loadMessage loads HeroConf's content from msg instead of files, as Load does
+// after reading files. msg is taken over, and stats of reading files are left as is.
+func (x *HeroConf) loadMessage(msg proto.Message) error {
+	start := time.Now()
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.data = msg.(*protoconf.HeroConf)
+	x.Stats.EntryCount = recordCount(x.data)
+	return x.afterLoad()
+}
+
+// afterLoad backs up and processes HeroConf's loaded content.
+func (x *HeroConf) afterLoad() error {
 	if x.backup {
 		x.originalData = proto.Clone(x.data).(*protoconf.HeroConf)
 	}
-	return x.processAfterLoad()
+	processStart := time.Now()
+	err := x.processAfterLoad()
+	x.Stats.ProcessAfterLoadDuration = time.Since(processStart)
+	if err != nil {
+		return &LoadError{Messager: x.Name(), Phase: PhaseProcessAfterLoad, Err: err}
+	}
+	return nil
+}
+
+// LoadContext loads HeroConf's content like Load, but aborts when ctx is done.
+// The files are loaded into a new message, which is taken only if loaded
+// successfully, so that HeroConf is left untouched if aborted.
+func (x *HeroConf) LoadContext(ctx context.Context, dir string, format format.Format, opts *load.MessagerOptions) error {
+	start := time.Now()
+	data := &protoconf.HeroConf{}
+	stats := Stats{}
+	err := loadContext(ctx, x.Name(), opts, func(opts *load.MessagerOptions) error {
+		return loadMessagerInDir(data, dir, format, opts, &stats)
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.Stats = stats
+	x.data = data
+	return x.afterLoad()
+}
+
+// LoadFS loads HeroConf's content in the given dir of fsys, based on format and messager options.
+func (x *HeroConf) LoadFS(fsys fs.FS, dir string, format format.Format, opts *load.MessagerOptions) error {
+	mopts, err := fsMessagerOptions(fsys, x.Name(), dir, format, opts)
+	if err != nil {
+		return err
+	}
+	return x.Load(dir, format, mopts)
 }
 
 // Store stores HeroConf's content to file in the specified directory and format.
@@ -222,15 +278,69 @@ func (x *HeroBaseConf) Load(dir string, format format.Format, opts *load.Message
 	defer func() {
 		x.Stats.Duration = time.Since(start)
 	}()
+	x.Stats = Stats{}
 	x.data = &protoconf.HeroBaseConf{}
-	err := load.LoadMessagerInDir(x.data, dir, format, opts)
+	err := loadMessagerInDir(x.data, dir, format, opts, &x.Stats)
 	if err != nil {
 		return err
 	}
+	return x.afterLoad()
+}
+
+// loadMessage loads HeroBaseConf's content from msg instead of files, as Load does
+// after reading files. msg is taken over, and stats of reading files are left as is.
+func (x *HeroBaseConf) loadMessage(msg proto.Message) error {
+	start := time.Now()
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.data = msg.(*protoconf.HeroBaseConf)
+	x.Stats.EntryCount = recordCount(x.data)
+	return x.afterLoad()
+}
+
+// afterLoad backs up and processes HeroBaseConf's loaded content.
+func (x *HeroBaseConf) afterLoad() error {
 	if x.backup {
 		x.originalData = proto.Clone(x.data).(*protoconf.HeroBaseConf)
 	}
-	return x.processAfterLoad()
+	processStart := time.Now()
+	err := x.processAfterLoad()
+	x.Stats.ProcessAfterLoadDuration = time.Since(processStart)
+	if err != nil {
+		return &LoadError{Messager: x.Name(), Phase: PhaseProcessAfterLoad, Err: err}
+	}
+	return nil
+}
+
+// LoadContext loads HeroBaseConf's content like Load, but aborts when ctx is done.
+// The files are loaded into a new message, which is taken only if loaded
+// successfully, so that HeroBaseConf is left untouched if aborted.
+func (x *HeroBaseConf) LoadContext(ctx context.Context, dir string, format format.Format, opts *load.MessagerOptions) error {
+	start := time.Now()
+	data := &protoconf.HeroBaseConf{}
+	stats := Stats{}
+	err := loadContext(ctx, x.Name(), opts, func(opts *load.MessagerOptions) error {
+		return loadMessagerInDir(data, dir, format, opts, &stats)
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.Stats = stats
+	x.data = data
+	return x.afterLoad()
+}
+
+// LoadFS loads HeroBaseConf's content in the given dir of fsys, based on format and messager options.
+func (x *HeroBaseConf) LoadFS(fsys fs.FS, dir string, format format.Format, opts *load.MessagerOptions) error {
+	mopts, err := fsMessagerOptions(fsys, x.Name(), dir, format, opts)
+	if err != nil {
+		return err
+	}
+	return x.Load(dir, format, mopts)
 }
 
 // Store stores HeroBaseConf's content to file in the specified directory and format.
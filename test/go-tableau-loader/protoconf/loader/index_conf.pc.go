@@ -7,6 +7,7 @@
 package loader
 
 import (
+	context "context"
 	fmt "fmt"
 	treemap "github.com/tableauio/loader/pkg/treemap"
 	protoconf "github.com/tableauio/loader/test/go-tableau-loader/protoconf"
@@ -14,6 +15,7 @@ import (
 	load "github.com/tableauio/tableau/load"
 	store "github.com/tableauio/tableau/store"
 	proto "google.golang.org/protobuf/proto"
+	fs "io/fs"
 	sort "sort"
 	time "time"
 )
@@ -61,15 +63,69 @@ func (x *FruitConf) Load(dir string, format format.Format, opts *load.MessagerOp
 	defer func() {
 		x.Stats.Duration = time.Since(start)
 	}()
+	x.Stats = Stats{}
 	x.data = &protoconf.FruitConf{}
-	err := load.LoadMessagerInDir(x.data, dir, format, opts)
+	err := loadMessagerInDir(x.data, dir, format, opts, &x.Stats)
 	if err != nil {
 		return err
 	}
+	return x.afterLoad()
+}
+
+// loadMessage loads FruitConf's content from msg instead of files, as Load does
+// after reading files. msg is taken over, and stats of reading files are left as is.
+func (x *FruitConf) loadMessage(msg proto.Message) error {
+	start := time.Now()
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.data = msg.(*protoconf.FruitConf)
+	x.Stats.EntryCount = recordCount(x.data)
+	return x.afterLoad()
+}
+
+// afterLoad backs up and processes FruitConf's loaded content.
+func (x *FruitConf) afterLoad() error {
 	if x.backup {
 		x.originalData = proto.Clone(x.data).(*protoconf.FruitConf)
 	}
-	return x.processAfterLoad()
+	processStart := time.Now()
+	err := x.processAfterLoad()
+	x.Stats.ProcessAfterLoadDuration = time.Since(processStart)
+	if err != nil {
+		return &LoadError{Messager: x.Name(), Phase: PhaseProcessAfterLoad, Err: err}
+	}
+	return nil
+}
+
+// LoadContext loads FruitConf's content like Load, but aborts when ctx is done.
+// The files are loaded into a new message, which is taken only if loaded
+// successfully, so that FruitConf is left untouched if aborted.
+func (x *FruitConf) LoadContext(ctx context.Context, dir string, format format.Format, opts *load.MessagerOptions) error {
+	start := time.Now()
+	data := &protoconf.FruitConf{}
+	stats := Stats{}
+	err := loadContext(ctx, x.Name(), opts, func(opts *load.MessagerOptions) error {
+		return loadMessagerInDir(data, dir, format, opts, &stats)
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.Stats = stats
+	x.data = data
+	return x.afterLoad()
+}
+
+// LoadFS loads FruitConf's content in the given dir of fsys, based on format and messager options.
+func (x *FruitConf) LoadFS(fsys fs.FS, dir string, format format.Format, opts *load.MessagerOptions) error {
+	mopts, err := fsMessagerOptions(fsys, x.Name(), dir, format, opts)
+	if err != nil {
+		return err
+	}
+	return x.Load(dir, format, mopts)
 }
 
 // Store stores FruitConf's content to file in the specified directory and format.
@@ -332,15 +388,69 @@ func (x *Fruit6Conf) Load(dir string, format format.Format, opts *load.MessagerO
 	defer func() {
 		x.Stats.Duration = time.Since(start)
 	}()
+	x.Stats = Stats{}
 	x.data = &protoconf.Fruit6Conf{}
-	err := load.LoadMessagerInDir(x.data, dir, format, opts)
+	err := loadMessagerInDir(x.data, dir, format, opts, &x.Stats)
 	if err != nil {
 		return err
 	}
+	return x.afterLoad()
+}
+
+// loadMessage loads Fruit6Conf's content from msg instead of files, as Load does
+// after reading files. msg is taken over, and stats of reading files are left as is.
+func (x *Fruit6Conf) loadMessage(msg proto.Message) error {
+	start := time.Now()
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.data = msg.(*protoconf.Fruit6Conf)
+	x.Stats.EntryCount = recordCount(x.data)
+	return x.afterLoad()
+}
+
+// afterLoad backs up and processes Fruit6Conf's loaded content.
+func (x *Fruit6Conf) afterLoad() error {
 	if x.backup {
 		x.originalData = proto.Clone(x.data).(*protoconf.Fruit6Conf)
 	}
-	return x.processAfterLoad()
+	processStart := time.Now()
+	err := x.processAfterLoad()
+	x.Stats.ProcessAfterLoadDuration = time.Since(processStart)
+	if err != nil {
+		return &LoadError{Messager: x.Name(), Phase: PhaseProcessAfterLoad, Err: err}
+	}
+	return nil
+}
+
+// LoadContext loads Fruit6Conf's content like Load, but aborts when ctx is done.
+// The files are loaded into a new message, which is taken only if loaded
+// successfully, so that Fruit6Conf is left untouched if aborted.
+func (x *Fruit6Conf) LoadContext(ctx context.Context, dir string, format format.Format, opts *load.MessagerOptions) error {
+	start := time.Now()
+	data := &protoconf.Fruit6Conf{}
+	stats := Stats{}
+	err := loadContext(ctx, x.Name(), opts, func(opts *load.MessagerOptions) error {
+		return loadMessagerInDir(data, dir, format, opts, &stats)
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.Stats = stats
+	x.data = data
+	return x.afterLoad()
+}
+
+// LoadFS loads Fruit6Conf's content in the given dir of fsys, based on format and messager options.
+func (x *Fruit6Conf) LoadFS(fsys fs.FS, dir string, format format.Format, opts *load.MessagerOptions) error {
+	mopts, err := fsMessagerOptions(fsys, x.Name(), dir, format, opts)
+	if err != nil {
+		return err
+	}
+	return x.Load(dir, format, mopts)
 }
 
 // Store stores Fruit6Conf's content to file in the specified directory and format.
@@ -600,15 +710,69 @@ func (x *Fruit2Conf) Load(dir string, format format.Format, opts *load.MessagerO
 	defer func() {
 		x.Stats.Duration = time.Since(start)
 	}()
+	x.Stats = Stats{}
 	x.data = &protoconf.Fruit2Conf{}
-	err := load.LoadMessagerInDir(x.data, dir, format, opts)
+	err := loadMessagerInDir(x.data, dir, format, opts, &x.Stats)
 	if err != nil {
 		return err
 	}
+	return x.afterLoad()
+}
+
+// loadMessage loads Fruit2Conf's content from msg instead of files, as Load does
+// after reading files. msg is taken over, and stats of reading files are left as is.
+func (x *Fruit2Conf) loadMessage(msg proto.Message) error {
+	start := time.Now()
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.data = msg.(*protoconf.Fruit2Conf)
+	x.Stats.EntryCount = recordCount(x.data)
+	return x.afterLoad()
+}
+
+// afterLoad backs up and processes Fruit2Conf's loaded content.
+func (x *Fruit2Conf) afterLoad() error {
 	if x.backup {
 		x.originalData = proto.Clone(x.data).(*protoconf.Fruit2Conf)
 	}
-	return x.processAfterLoad()
+	processStart := time.Now()
+	err := x.processAfterLoad()
+	x.Stats.ProcessAfterLoadDuration = time.Since(processStart)
+	if err != nil {
+		return &LoadError{Messager: x.Name(), Phase: PhaseProcessAfterLoad, Err: err}
+	}
+	return nil
+}
+
+// LoadContext loads Fruit2Conf's content like Load, but aborts when ctx is done.
+// The files are loaded into a new message, which is taken only if loaded
+// successfully, so that Fruit2Conf is left untouched if aborted.
+func (x *Fruit2Conf) LoadContext(ctx context.Context, dir string, format format.Format, opts *load.MessagerOptions) error {
+	start := time.Now()
+	data := &protoconf.Fruit2Conf{}
+	stats := Stats{}
+	err := loadContext(ctx, x.Name(), opts, func(opts *load.MessagerOptions) error {
+		return loadMessagerInDir(data, dir, format, opts, &stats)
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.Stats = stats
+	x.data = data
+	return x.afterLoad()
+}
+
+// LoadFS loads Fruit2Conf's content in the given dir of fsys, based on format and messager options.
+func (x *Fruit2Conf) LoadFS(fsys fs.FS, dir string, format format.Format, opts *load.MessagerOptions) error {
+	mopts, err := fsMessagerOptions(fsys, x.Name(), dir, format, opts)
+	if err != nil {
+		return err
+	}
+	return x.Load(dir, format, mopts)
 }
 
 // Store stores Fruit2Conf's content to file in the specified directory and format.
@@ -937,15 +1101,69 @@ func (x *Fruit3Conf) Load(dir string, format format.Format, opts *load.MessagerO
 	defer func() {
 		x.Stats.Duration = time.Since(start)
 	}()
+	x.Stats = Stats{}
 	x.data = &protoconf.Fruit3Conf{}
-	err := load.LoadMessagerInDir(x.data, dir, format, opts)
+	err := loadMessagerInDir(x.data, dir, format, opts, &x.Stats)
 	if err != nil {
 		return err
 	}
+	return x.afterLoad()
+}
+
+// loadMessage loads Fruit3Conf's content from msg instead of files, as Load does
+// after reading files. msg is taken over, and stats of reading files are left as is.
+func (x *Fruit3Conf) loadMessage(msg proto.Message) error {
+	start := time.Now()
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.data = msg.(*protoconf.Fruit3Conf)
+	x.Stats.EntryCount = recordCount(x.data)
+	return x.afterLoad()
+}
+
+// afterLoad backs up and processes Fruit3Conf's loaded content.
+func (x *Fruit3Conf) afterLoad() error {
 	if x.backup {
 		x.originalData = proto.Clone(x.data).(*protoconf.Fruit3Conf)
 	}
-	return x.processAfterLoad()
+	processStart := time.Now()
+	err := x.processAfterLoad()
+	x.Stats.ProcessAfterLoadDuration = time.Since(processStart)
+	if err != nil {
+		return &LoadError{Messager: x.Name(), Phase: PhaseProcessAfterLoad, Err: err}
+	}
+	return nil
+}
+
+// LoadContext loads Fruit3Conf's content like Load, but aborts when ctx is done.
+// The files are loaded into a new message, which is taken only if loaded
+// successfully, so that Fruit3Conf is left untouched if aborted.
+func (x *Fruit3Conf) LoadContext(ctx context.Context, dir string, format format.Format, opts *load.MessagerOptions) error {
+	start := time.Now()
+	data := &protoconf.Fruit3Conf{}
+	stats := Stats{}
+	err := loadContext(ctx, x.Name(), opts, func(opts *load.MessagerOptions) error {
+		return loadMessagerInDir(data, dir, format, opts, &stats)
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.Stats = stats
+	x.data = data
+	return x.afterLoad()
+}
+
+// LoadFS loads Fruit3Conf's content in the given dir of fsys, based on format and messager options.
+func (x *Fruit3Conf) LoadFS(fsys fs.FS, dir string, format format.Format, opts *load.MessagerOptions) error {
+	mopts, err := fsMessagerOptions(fsys, x.Name(), dir, format, opts)
+	if err != nil {
+		return err
+	}
+	return x.Load(dir, format, mopts)
 }
 
 // Store stores Fruit3Conf's content to file in the specified directory and format.
@@ -1182,15 +1400,69 @@ func (x *Fruit4Conf) Load(dir string, format format.Format, opts *load.MessagerO
 	defer func() {
 		x.Stats.Duration = time.Since(start)
 	}()
+	x.Stats = Stats{}
 	x.data = &protoconf.Fruit4Conf{}
-	err := load.LoadMessagerInDir(x.data, dir, format, opts)
+	err := loadMessagerInDir(x.data, dir, format, opts, &x.Stats)
 	if err != nil {
 		return err
 	}
+	return x.afterLoad()
+}
+
+// loadMessage loads Fruit4Conf's content from msg instead of files, as Load does
+// after reading files. msg is taken over, and stats of reading files are left as is.
+func (x *Fruit4Conf) loadMessage(msg proto.Message) error {
+	start := time.Now()
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.data = msg.(*protoconf.Fruit4Conf)
+	x.Stats.EntryCount = recordCount(x.data)
+	return x.afterLoad()
+}
+
+// afterLoad backs up and processes Fruit4Conf's loaded content.
+func (x *Fruit4Conf) afterLoad() error {
 	if x.backup {
 		x.originalData = proto.Clone(x.data).(*protoconf.Fruit4Conf)
 	}
-	return x.processAfterLoad()
+	processStart := time.Now()
+	err := x.processAfterLoad()
+	x.Stats.ProcessAfterLoadDuration = time.Since(processStart)
+	if err != nil {
+		return &LoadError{Messager: x.Name(), Phase: PhaseProcessAfterLoad, Err: err}
+	}
+	return nil
+}
+
+// LoadContext loads Fruit4Conf's content like Load, but aborts when ctx is done.
+// The files are loaded into a new message, which is taken only if loaded
+// successfully, so that Fruit4Conf is left untouched if aborted.
+func (x *Fruit4Conf) LoadContext(ctx context.Context, dir string, format format.Format, opts *load.MessagerOptions) error {
+	start := time.Now()
+	data := &protoconf.Fruit4Conf{}
+	stats := Stats{}
+	err := loadContext(ctx, x.Name(), opts, func(opts *load.MessagerOptions) error {
+		return loadMessagerInDir(data, dir, format, opts, &stats)
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.Stats = stats
+	x.data = data
+	return x.afterLoad()
+}
+
+// LoadFS loads Fruit4Conf's content in the given dir of fsys, based on format and messager options.
+func (x *Fruit4Conf) LoadFS(fsys fs.FS, dir string, format format.Format, opts *load.MessagerOptions) error {
+	mopts, err := fsMessagerOptions(fsys, x.Name(), dir, format, opts)
+	if err != nil {
+		return err
+	}
+	return x.Load(dir, format, mopts)
 }
 
 // Store stores Fruit4Conf's content to file in the specified directory and format.
@@ -1610,15 +1882,69 @@ func (x *Fruit5Conf) Load(dir string, format format.Format, opts *load.MessagerO
 	defer func() {
 		x.Stats.Duration = time.Since(start)
 	}()
+	x.Stats = Stats{}
 	x.data = &protoconf.Fruit5Conf{}
-	err := load.LoadMessagerInDir(x.data, dir, format, opts)
+	err := loadMessagerInDir(x.data, dir, format, opts, &x.Stats)
 	if err != nil {
 		return err
 	}
+	return x.afterLoad()
+}
+
+// loadMessage loads Fruit5Conf's content from msg instead of files, as Load does
+// after reading files. msg is taken over, and stats of reading files are left as is.
+func (x *Fruit5Conf) loadMessage(msg proto.Message) error {
+	start := time.Now()
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.data = msg.(*protoconf.Fruit5Conf)
+	x.Stats.EntryCount = recordCount(x.data)
+	return x.afterLoad()
+}
+
+// afterLoad backs up and processes Fruit5Conf's loaded content.
+func (x *Fruit5Conf) afterLoad() error {
 	if x.backup {
 		x.originalData = proto.Clone(x.data).(*protoconf.Fruit5Conf)
 	}
-	return x.processAfterLoad()
+	processStart := time.Now()
+	err := x.processAfterLoad()
+	x.Stats.ProcessAfterLoadDuration = time.Since(processStart)
+	if err != nil {
+		return &LoadError{Messager: x.Name(), Phase: PhaseProcessAfterLoad, Err: err}
+	}
+	return nil
+}
+
+// LoadContext loads Fruit5Conf's content like Load, but aborts when ctx is done.
+// The files are loaded into a new message, which is taken only if loaded
+// successfully, so that Fruit5Conf is left untouched if aborted.
+func (x *Fruit5Conf) LoadContext(ctx context.Context, dir string, format format.Format, opts *load.MessagerOptions) error {
+	start := time.Now()
+	data := &protoconf.Fruit5Conf{}
+	stats := Stats{}
+	err := loadContext(ctx, x.Name(), opts, func(opts *load.MessagerOptions) error {
+		return loadMessagerInDir(data, dir, format, opts, &stats)
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.Stats = stats
+	x.data = data
+	return x.afterLoad()
+}
+
+// LoadFS loads Fruit5Conf's content in the given dir of fsys, based on format and messager options.
+func (x *Fruit5Conf) LoadFS(fsys fs.FS, dir string, format format.Format, opts *load.MessagerOptions) error {
+	mopts, err := fsMessagerOptions(fsys, x.Name(), dir, format, opts)
+	if err != nil {
+		return err
+	}
+	return x.Load(dir, format, mopts)
 }
 
 // Store stores Fruit5Conf's content to file in the specified directory and format.
@@ -7,6 +7,8 @@
 package loader
 
 import (
+	context "context"
+	errors "errors"
 	fmt "fmt"
 	treemap "github.com/tableauio/loader/pkg/treemap"
 	protoconf "github.com/tableauio/loader/test/go-tableau-loader/protoconf"
@@ -14,6 +16,7 @@ import (
 	load "github.com/tableauio/tableau/load"
 	store "github.com/tableauio/tableau/store"
 	proto "google.golang.org/protobuf/proto"
+	fs "io/fs"
 	sort "sort"
 	time "time"
 )
@@ -125,15 +128,69 @@ func (x *ItemConf) Load(dir string, format format.Format, opts *load.MessagerOpt
 	defer func() {
 		x.Stats.Duration = time.Since(start)
 	}()
+	x.Stats = Stats{}
 	x.data = &protoconf.ItemConf{}
-	err := load.LoadMessagerInDir(x.data, dir, format, opts)
+	err := loadMessagerInDir(x.data, dir, format, opts, &x.Stats)
 	if err != nil {
 		return err
 	}
+	return x.afterLoad()
+}
+
+// loadMessage loads ItemConf's content from msg instead of files, as Load does
+// after reading files. msg is taken over, and stats of reading files are left as is.
+func (x *ItemConf) loadMessage(msg proto.Message) error {
+	start := time.Now()
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.data = msg.(*protoconf.ItemConf)
+	x.Stats.EntryCount = recordCount(x.data)
+	return x.afterLoad()
+}
+
+// afterLoad backs up and processes ItemConf's loaded content.
+func (x *ItemConf) afterLoad() error {
 	if x.backup {
 		x.originalData = proto.Clone(x.data).(*protoconf.ItemConf)
 	}
-	return x.processAfterLoad()
+	processStart := time.Now()
+	err := x.processAfterLoad()
+	x.Stats.ProcessAfterLoadDuration = time.Since(processStart)
+	if err != nil {
+		return &LoadError{Messager: x.Name(), Phase: PhaseProcessAfterLoad, Err: err}
+	}
+	return nil
+}
+
+// LoadContext loads ItemConf's content like Load, but aborts when ctx is done.
+// The files are loaded into a new message, which is taken only if loaded
+// successfully, so that ItemConf is left untouched if aborted.
+func (x *ItemConf) LoadContext(ctx context.Context, dir string, format format.Format, opts *load.MessagerOptions) error {
+	start := time.Now()
+	data := &protoconf.ItemConf{}
+	stats := Stats{}
+	err := loadContext(ctx, x.Name(), opts, func(opts *load.MessagerOptions) error {
+		return loadMessagerInDir(data, dir, format, opts, &stats)
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.Stats = stats
+	x.data = data
+	return x.afterLoad()
+}
+
+// LoadFS loads ItemConf's content in the given dir of fsys, based on format and messager options.
+func (x *ItemConf) LoadFS(fsys fs.FS, dir string, format format.Format, opts *load.MessagerOptions) error {
+	mopts, err := fsMessagerOptions(fsys, x.Name(), dir, format, opts)
+	if err != nil {
+		return err
+	}
+	return x.Load(dir, format, mopts)
 }
 
 // Store stores ItemConf's content to file in the specified directory and format.
@@ -298,6 +355,24 @@ func (x *ItemConf) processAfterLoad() error {
 	return nil
 }
 
+// checkRefer checks that values of fields with refer prop exist as keys
+// of the referred messagers, and reports dangling references with their
+// full key paths. Zero values are treated as empty and skipped.
+func (x *ItemConf) checkRefer(mc *MessagerContainer) error {
+	var errs []error
+	for _, k1 := range sortedKeys(x.Data().GetItemMap()) {
+		v1 := x.Data().GetItemMap()[k1]
+		if ref := v1.GetUseEffect().GetGainItem().GetItemId(); ref != 0 {
+			if conf := mc.GetItemConf(); conf != nil {
+				if _, ok := conf.Data().GetItemMap()[ref]; !ok {
+					errs = append(errs, fmt.Errorf("item_map[%v].use_effect.gain_item.item_id: ItemConf.ID(%v) %w", k1, ref, ErrNotFound))
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // Get1 finds value in the 1st-level map. It will return
 // NotFound error if the key is not found.
 func (x *ItemConf) Get1(id uint32) (*protoconf.ItemConf_Item, error) {
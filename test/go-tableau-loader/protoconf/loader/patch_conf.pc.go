@@ -7,12 +7,14 @@
 package loader
 
 import (
+	context "context"
 	fmt "fmt"
 	protoconf "github.com/tableauio/loader/test/go-tableau-loader/protoconf"
 	format "github.com/tableauio/tableau/format"
 	load "github.com/tableauio/tableau/load"
 	store "github.com/tableauio/tableau/store"
 	proto "google.golang.org/protobuf/proto"
+	fs "io/fs"
 	time "time"
 )
 
@@ -47,15 +49,69 @@ func (x *PatchReplaceConf) Load(dir string, format format.Format, opts *load.Mes
 	defer func() {
 		x.Stats.Duration = time.Since(start)
 	}()
+	x.Stats = Stats{}
 	x.data = &protoconf.PatchReplaceConf{}
-	err := load.LoadMessagerInDir(x.data, dir, format, opts)
+	err := loadMessagerInDir(x.data, dir, format, opts, &x.Stats)
 	if err != nil {
 		return err
 	}
+	return x.afterLoad()
+}
+
+// loadMessage loads PatchReplaceConf's content from msg instead of files, as Load does
+// after reading files. msg is taken over, and stats of reading files are left as is.
+func (x *PatchReplaceConf) loadMessage(msg proto.Message) error {
+	start := time.Now()
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.data = msg.(*protoconf.PatchReplaceConf)
+	x.Stats.EntryCount = recordCount(x.data)
+	return x.afterLoad()
+}
+
+// afterLoad backs up and processes PatchReplaceConf's loaded content.
+func (x *PatchReplaceConf) afterLoad() error {
 	if x.backup {
 		x.originalData = proto.Clone(x.data).(*protoconf.PatchReplaceConf)
 	}
-	return x.processAfterLoad()
+	processStart := time.Now()
+	err := x.processAfterLoad()
+	x.Stats.ProcessAfterLoadDuration = time.Since(processStart)
+	if err != nil {
+		return &LoadError{Messager: x.Name(), Phase: PhaseProcessAfterLoad, Err: err}
+	}
+	return nil
+}
+
+// LoadContext loads PatchReplaceConf's content like Load, but aborts when ctx is done.
+// The files are loaded into a new message, which is taken only if loaded
+// successfully, so that PatchReplaceConf is left untouched if aborted.
+func (x *PatchReplaceConf) LoadContext(ctx context.Context, dir string, format format.Format, opts *load.MessagerOptions) error {
+	start := time.Now()
+	data := &protoconf.PatchReplaceConf{}
+	stats := Stats{}
+	err := loadContext(ctx, x.Name(), opts, func(opts *load.MessagerOptions) error {
+		return loadMessagerInDir(data, dir, format, opts, &stats)
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.Stats = stats
+	x.data = data
+	return x.afterLoad()
+}
+
+// LoadFS loads PatchReplaceConf's content in the given dir of fsys, based on format and messager options.
+func (x *PatchReplaceConf) LoadFS(fsys fs.FS, dir string, format format.Format, opts *load.MessagerOptions) error {
+	mopts, err := fsMessagerOptions(fsys, x.Name(), dir, format, opts)
+	if err != nil {
+		return err
+	}
+	return x.Load(dir, format, mopts)
 }
 
 // Store stores PatchReplaceConf's content to file in the specified directory and format.
@@ -113,15 +169,69 @@ func (x *PatchMergeConf) Load(dir string, format format.Format, opts *load.Messa
 	defer func() {
 		x.Stats.Duration = time.Since(start)
 	}()
+	x.Stats = Stats{}
 	x.data = &protoconf.PatchMergeConf{}
-	err := load.LoadMessagerInDir(x.data, dir, format, opts)
+	err := loadMessagerInDir(x.data, dir, format, opts, &x.Stats)
 	if err != nil {
 		return err
 	}
+	return x.afterLoad()
+}
+
+// loadMessage loads PatchMergeConf's content from msg instead of files, as Load does
+// after reading files. msg is taken over, and stats of reading files are left as is.
+func (x *PatchMergeConf) loadMessage(msg proto.Message) error {
+	start := time.Now()
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.data = msg.(*protoconf.PatchMergeConf)
+	x.Stats.EntryCount = recordCount(x.data)
+	return x.afterLoad()
+}
+
+// afterLoad backs up and processes PatchMergeConf's loaded content.
+func (x *PatchMergeConf) afterLoad() error {
 	if x.backup {
 		x.originalData = proto.Clone(x.data).(*protoconf.PatchMergeConf)
 	}
-	return x.processAfterLoad()
+	processStart := time.Now()
+	err := x.processAfterLoad()
+	x.Stats.ProcessAfterLoadDuration = time.Since(processStart)
+	if err != nil {
+		return &LoadError{Messager: x.Name(), Phase: PhaseProcessAfterLoad, Err: err}
+	}
+	return nil
+}
+
+// LoadContext loads PatchMergeConf's content like Load, but aborts when ctx is done.
+// The files are loaded into a new message, which is taken only if loaded
+// successfully, so that PatchMergeConf is left untouched if aborted.
+func (x *PatchMergeConf) LoadContext(ctx context.Context, dir string, format format.Format, opts *load.MessagerOptions) error {
+	start := time.Now()
+	data := &protoconf.PatchMergeConf{}
+	stats := Stats{}
+	err := loadContext(ctx, x.Name(), opts, func(opts *load.MessagerOptions) error {
+		return loadMessagerInDir(data, dir, format, opts, &stats)
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.Stats = stats
+	x.data = data
+	return x.afterLoad()
+}
+
+// LoadFS loads PatchMergeConf's content in the given dir of fsys, based on format and messager options.
+func (x *PatchMergeConf) LoadFS(fsys fs.FS, dir string, format format.Format, opts *load.MessagerOptions) error {
+	mopts, err := fsMessagerOptions(fsys, x.Name(), dir, format, opts)
+	if err != nil {
+		return err
+	}
+	return x.Load(dir, format, mopts)
 }
 
 // Store stores PatchMergeConf's content to file in the specified directory and format.
@@ -190,15 +300,69 @@ func (x *RecursivePatchConf) Load(dir string, format format.Format, opts *load.M
 	defer func() {
 		x.Stats.Duration = time.Since(start)
 	}()
+	x.Stats = Stats{}
 	x.data = &protoconf.RecursivePatchConf{}
-	err := load.LoadMessagerInDir(x.data, dir, format, opts)
+	err := loadMessagerInDir(x.data, dir, format, opts, &x.Stats)
 	if err != nil {
 		return err
 	}
+	return x.afterLoad()
+}
+
+// loadMessage loads RecursivePatchConf's content from msg instead of files, as Load does
+// after reading files. msg is taken over, and stats of reading files are left as is.
+func (x *RecursivePatchConf) loadMessage(msg proto.Message) error {
+	start := time.Now()
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.data = msg.(*protoconf.RecursivePatchConf)
+	x.Stats.EntryCount = recordCount(x.data)
+	return x.afterLoad()
+}
+
+// afterLoad backs up and processes RecursivePatchConf's loaded content.
+func (x *RecursivePatchConf) afterLoad() error {
 	if x.backup {
 		x.originalData = proto.Clone(x.data).(*protoconf.RecursivePatchConf)
 	}
-	return x.processAfterLoad()
+	processStart := time.Now()
+	err := x.processAfterLoad()
+	x.Stats.ProcessAfterLoadDuration = time.Since(processStart)
+	if err != nil {
+		return &LoadError{Messager: x.Name(), Phase: PhaseProcessAfterLoad, Err: err}
+	}
+	return nil
+}
+
+// LoadContext loads RecursivePatchConf's content like Load, but aborts when ctx is done.
+// The files are loaded into a new message, which is taken only if loaded
+// successfully, so that RecursivePatchConf is left untouched if aborted.
+func (x *RecursivePatchConf) LoadContext(ctx context.Context, dir string, format format.Format, opts *load.MessagerOptions) error {
+	start := time.Now()
+	data := &protoconf.RecursivePatchConf{}
+	stats := Stats{}
+	err := loadContext(ctx, x.Name(), opts, func(opts *load.MessagerOptions) error {
+		return loadMessagerInDir(data, dir, format, opts, &stats)
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.Stats = stats
+	x.data = data
+	return x.afterLoad()
+}
+
+// LoadFS loads RecursivePatchConf's content in the given dir of fsys, based on format and messager options.
+func (x *RecursivePatchConf) LoadFS(fsys fs.FS, dir string, format format.Format, opts *load.MessagerOptions) error {
+	mopts, err := fsMessagerOptions(fsys, x.Name(), dir, format, opts)
+	if err != nil {
+		return err
+	}
+	return x.Load(dir, format, mopts)
 }
 
 // Store stores RecursivePatchConf's content to file in the specified directory and format.
@@ -7,6 +7,8 @@
 package loader
 
 import (
+	context "context"
+	errors "errors"
 	fmt "fmt"
 	pair "github.com/tableauio/loader/pkg/pair"
 	treemap "github.com/tableauio/loader/pkg/treemap"
@@ -15,6 +17,7 @@ import (
 	load "github.com/tableauio/tableau/load"
 	store "github.com/tableauio/tableau/store"
 	proto "google.golang.org/protobuf/proto"
+	fs "io/fs"
 	sort "sort"
 	time "time"
 )
@@ -96,15 +99,69 @@ func (x *ActivityConf) Load(dir string, format format.Format, opts *load.Message
 	defer func() {
 		x.Stats.Duration = time.Since(start)
 	}()
+	x.Stats = Stats{}
 	x.data = &protoconf.ActivityConf{}
-	err := load.LoadMessagerInDir(x.data, dir, format, opts)
+	err := loadMessagerInDir(x.data, dir, format, opts, &x.Stats)
 	if err != nil {
 		return err
 	}
+	return x.afterLoad()
+}
+
+// loadMessage loads ActivityConf's content from msg instead of files, as Load does
+// after reading files. msg is taken over, and stats of reading files are left as is.
+func (x *ActivityConf) loadMessage(msg proto.Message) error {
+	start := time.Now()
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.data = msg.(*protoconf.ActivityConf)
+	x.Stats.EntryCount = recordCount(x.data)
+	return x.afterLoad()
+}
+
+// afterLoad backs up and processes ActivityConf's loaded content.
+func (x *ActivityConf) afterLoad() error {
 	if x.backup {
 		x.originalData = proto.Clone(x.data).(*protoconf.ActivityConf)
 	}
-	return x.processAfterLoad()
+	processStart := time.Now()
+	err := x.processAfterLoad()
+	x.Stats.ProcessAfterLoadDuration = time.Since(processStart)
+	if err != nil {
+		return &LoadError{Messager: x.Name(), Phase: PhaseProcessAfterLoad, Err: err}
+	}
+	return nil
+}
+
+// LoadContext loads ActivityConf's content like Load, but aborts when ctx is done.
+// The files are loaded into a new message, which is taken only if loaded
+// successfully, so that ActivityConf is left untouched if aborted.
+func (x *ActivityConf) LoadContext(ctx context.Context, dir string, format format.Format, opts *load.MessagerOptions) error {
+	start := time.Now()
+	data := &protoconf.ActivityConf{}
+	stats := Stats{}
+	err := loadContext(ctx, x.Name(), opts, func(opts *load.MessagerOptions) error {
+		return loadMessagerInDir(data, dir, format, opts, &stats)
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.Stats = stats
+	x.data = data
+	return x.afterLoad()
+}
+
+// LoadFS loads ActivityConf's content in the given dir of fsys, based on format and messager options.
+func (x *ActivityConf) LoadFS(fsys fs.FS, dir string, format format.Format, opts *load.MessagerOptions) error {
+	mopts, err := fsMessagerOptions(fsys, x.Name(), dir, format, opts)
+	if err != nil {
+		return err
+	}
+	return x.Load(dir, format, mopts)
 }
 
 // Store stores ActivityConf's content to file in the specified directory and format.
@@ -240,6 +297,50 @@ func (x *ActivityConf) processAfterLoad() error {
 	return nil
 }
 
+// checkRefer checks that values of fields with refer prop exist as keys
+// of the referred messagers, and reports dangling references with their
+// full key paths. Zero values are treated as empty and skipped.
+func (x *ActivityConf) checkRefer(mc *MessagerContainer) error {
+	var errs []error
+	for _, k1 := range sortedKeys(x.Data().GetActivityMap()) {
+		v1 := x.Data().GetActivityMap()[k1]
+		for _, k2 := range sortedKeys(v1.GetChapterMap()) {
+			v2 := v1.GetChapterMap()[k2]
+			if ref := v2.GetChapterId(); ref != 0 {
+				if conf := mc.GetChapterConf(); conf != nil {
+					key := uint64(ref)
+					if _, ok := conf.Data().GetChapterMap()[key]; !ok || uint32(key) != ref {
+						errs = append(errs, fmt.Errorf("activity_map[%v].chapter_map[%v].chapter_id: ChapterConf.ID(%v) %w", k1, k2, ref, ErrNotFound))
+					}
+				}
+			}
+			if ref := v2.GetAwardId(); ref != 0 {
+				if conf := mc.GetItemConf(); conf != nil {
+					if _, ok := conf.Data().GetItemMap()[ref]; !ok {
+						errs = append(errs, fmt.Errorf("activity_map[%v].chapter_map[%v].award_id: ItemConf.ID(%v) %w", k1, k2, ref, ErrNotFound))
+					}
+				}
+			}
+		}
+	}
+	if ref := x.Data().GetThemeName(); ref != "" {
+		if conf := mc.GetThemeConf(); conf != nil {
+			if _, ok := conf.Data().GetThemeMap()[ref]; !ok {
+				errs = append(errs, fmt.Errorf("theme_name: ThemeConf.Name(%v) %w", ref, ErrNotFound))
+			}
+		}
+	}
+	if ref := x.Data().GetCostItemId(); ref != 0 {
+		if conf := mc.GetItemConf(); conf != nil {
+			key := uint32(ref)
+			if _, ok := conf.Data().GetItemMap()[key]; !ok || int32(key) != ref || ref < 0 {
+				errs = append(errs, fmt.Errorf("cost_item_id: ItemConf.ID(%v) %w", ref, ErrNotFound))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // Get1 finds value in the 1st-level map. It will return
 // NotFound error if the key is not found.
 func (x *ActivityConf) Get1(activityId uint64) (*protoconf.ActivityConf_Activity, error) {
@@ -578,15 +679,69 @@ func (x *ChapterConf) Load(dir string, format format.Format, opts *load.Messager
 	defer func() {
 		x.Stats.Duration = time.Since(start)
 	}()
+	x.Stats = Stats{}
 	x.data = &protoconf.ChapterConf{}
-	err := load.LoadMessagerInDir(x.data, dir, format, opts)
+	err := loadMessagerInDir(x.data, dir, format, opts, &x.Stats)
 	if err != nil {
 		return err
 	}
+	return x.afterLoad()
+}
+
+// loadMessage loads ChapterConf's content from msg instead of files, as Load does
+// after reading files. msg is taken over, and stats of reading files are left as is.
+func (x *ChapterConf) loadMessage(msg proto.Message) error {
+	start := time.Now()
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.data = msg.(*protoconf.ChapterConf)
+	x.Stats.EntryCount = recordCount(x.data)
+	return x.afterLoad()
+}
+
+// afterLoad backs up and processes ChapterConf's loaded content.
+func (x *ChapterConf) afterLoad() error {
 	if x.backup {
 		x.originalData = proto.Clone(x.data).(*protoconf.ChapterConf)
 	}
-	return x.processAfterLoad()
+	processStart := time.Now()
+	err := x.processAfterLoad()
+	x.Stats.ProcessAfterLoadDuration = time.Since(processStart)
+	if err != nil {
+		return &LoadError{Messager: x.Name(), Phase: PhaseProcessAfterLoad, Err: err}
+	}
+	return nil
+}
+
+// LoadContext loads ChapterConf's content like Load, but aborts when ctx is done.
+// The files are loaded into a new message, which is taken only if loaded
+// successfully, so that ChapterConf is left untouched if aborted.
+func (x *ChapterConf) LoadContext(ctx context.Context, dir string, format format.Format, opts *load.MessagerOptions) error {
+	start := time.Now()
+	data := &protoconf.ChapterConf{}
+	stats := Stats{}
+	err := loadContext(ctx, x.Name(), opts, func(opts *load.MessagerOptions) error {
+		return loadMessagerInDir(data, dir, format, opts, &stats)
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.Stats = stats
+	x.data = data
+	return x.afterLoad()
+}
+
+// LoadFS loads ChapterConf's content in the given dir of fsys, based on format and messager options.
+func (x *ChapterConf) LoadFS(fsys fs.FS, dir string, format format.Format, opts *load.MessagerOptions) error {
+	mopts, err := fsMessagerOptions(fsys, x.Name(), dir, format, opts)
+	if err != nil {
+		return err
+	}
+	return x.Load(dir, format, mopts)
 }
 
 // Store stores ChapterConf's content to file in the specified directory and format.
@@ -641,6 +796,11 @@ func (x *ThemeConf) Name() string {
 	return string((*protoconf.ThemeConf)(nil).ProtoReflect().Descriptor().Name())
 }
 
+// optional reports that ThemeConf tolerates a missing config file.
+func (x *ThemeConf) optional() bool {
+	return true
+}
+
 // Data returns the ThemeConf's inner message data.
 func (x *ThemeConf) Data() *protoconf.ThemeConf {
 	if x != nil {
@@ -655,15 +815,69 @@ func (x *ThemeConf) Load(dir string, format format.Format, opts *load.MessagerOp
 	defer func() {
 		x.Stats.Duration = time.Since(start)
 	}()
+	x.Stats = Stats{}
 	x.data = &protoconf.ThemeConf{}
-	err := load.LoadMessagerInDir(x.data, dir, format, opts)
+	err := loadMessagerInDir(x.data, dir, format, opts, &x.Stats)
 	if err != nil {
 		return err
 	}
+	return x.afterLoad()
+}
+
+// loadMessage loads ThemeConf's content from msg instead of files, as Load does
+// after reading files. msg is taken over, and stats of reading files are left as is.
+func (x *ThemeConf) loadMessage(msg proto.Message) error {
+	start := time.Now()
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.data = msg.(*protoconf.ThemeConf)
+	x.Stats.EntryCount = recordCount(x.data)
+	return x.afterLoad()
+}
+
+// afterLoad backs up and processes ThemeConf's loaded content.
+func (x *ThemeConf) afterLoad() error {
 	if x.backup {
 		x.originalData = proto.Clone(x.data).(*protoconf.ThemeConf)
 	}
-	return x.processAfterLoad()
+	processStart := time.Now()
+	err := x.processAfterLoad()
+	x.Stats.ProcessAfterLoadDuration = time.Since(processStart)
+	if err != nil {
+		return &LoadError{Messager: x.Name(), Phase: PhaseProcessAfterLoad, Err: err}
+	}
+	return nil
+}
+
+// LoadContext loads ThemeConf's content like Load, but aborts when ctx is done.
+// The files are loaded into a new message, which is taken only if loaded
+// successfully, so that ThemeConf is left untouched if aborted.
+func (x *ThemeConf) LoadContext(ctx context.Context, dir string, format format.Format, opts *load.MessagerOptions) error {
+	start := time.Now()
+	data := &protoconf.ThemeConf{}
+	stats := Stats{}
+	err := loadContext(ctx, x.Name(), opts, func(opts *load.MessagerOptions) error {
+		return loadMessagerInDir(data, dir, format, opts, &stats)
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.Stats = stats
+	x.data = data
+	return x.afterLoad()
+}
+
+// LoadFS loads ThemeConf's content in the given dir of fsys, based on format and messager options.
+func (x *ThemeConf) LoadFS(fsys fs.FS, dir string, format format.Format, opts *load.MessagerOptions) error {
+	mopts, err := fsMessagerOptions(fsys, x.Name(), dir, format, opts)
+	if err != nil {
+		return err
+	}
+	return x.Load(dir, format, mopts)
 }
 
 // Store stores ThemeConf's content to file in the specified directory and format.
@@ -781,15 +995,69 @@ func (x *TaskConf) Load(dir string, format format.Format, opts *load.MessagerOpt
 	defer func() {
 		x.Stats.Duration = time.Since(start)
 	}()
+	x.Stats = Stats{}
 	x.data = &protoconf.TaskConf{}
-	err := load.LoadMessagerInDir(x.data, dir, format, opts)
+	err := loadMessagerInDir(x.data, dir, format, opts, &x.Stats)
 	if err != nil {
 		return err
 	}
+	return x.afterLoad()
+}
+
+// loadMessage loads TaskConf's content from msg instead of files, as Load does
+// after reading files. msg is taken over, and stats of reading files are left as is.
+func (x *TaskConf) loadMessage(msg proto.Message) error {
+	start := time.Now()
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.data = msg.(*protoconf.TaskConf)
+	x.Stats.EntryCount = recordCount(x.data)
+	return x.afterLoad()
+}
+
+// afterLoad backs up and processes TaskConf's loaded content.
+func (x *TaskConf) afterLoad() error {
 	if x.backup {
 		x.originalData = proto.Clone(x.data).(*protoconf.TaskConf)
 	}
-	return x.processAfterLoad()
+	processStart := time.Now()
+	err := x.processAfterLoad()
+	x.Stats.ProcessAfterLoadDuration = time.Since(processStart)
+	if err != nil {
+		return &LoadError{Messager: x.Name(), Phase: PhaseProcessAfterLoad, Err: err}
+	}
+	return nil
+}
+
+// LoadContext loads TaskConf's content like Load, but aborts when ctx is done.
+// The files are loaded into a new message, which is taken only if loaded
+// successfully, so that TaskConf is left untouched if aborted.
+func (x *TaskConf) LoadContext(ctx context.Context, dir string, format format.Format, opts *load.MessagerOptions) error {
+	start := time.Now()
+	data := &protoconf.TaskConf{}
+	stats := Stats{}
+	err := loadContext(ctx, x.Name(), opts, func(opts *load.MessagerOptions) error {
+		return loadMessagerInDir(data, dir, format, opts, &stats)
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.Stats = stats
+	x.data = data
+	return x.afterLoad()
+}
+
+// LoadFS loads TaskConf's content in the given dir of fsys, based on format and messager options.
+func (x *TaskConf) LoadFS(fsys fs.FS, dir string, format format.Format, opts *load.MessagerOptions) error {
+	mopts, err := fsMessagerOptions(fsys, x.Name(), dir, format, opts)
+	if err != nil {
+		return err
+	}
+	return x.Load(dir, format, mopts)
 }
 
 // Store stores TaskConf's content to file in the specified directory and format.
@@ -1098,15 +1366,69 @@ func (x *StrcaseConf) Load(dir string, format format.Format, opts *load.Messager
 	defer func() {
 		x.Stats.Duration = time.Since(start)
 	}()
+	x.Stats = Stats{}
 	x.data = &protoconf.StrcaseConf{}
-	err := load.LoadMessagerInDir(x.data, dir, format, opts)
+	err := loadMessagerInDir(x.data, dir, format, opts, &x.Stats)
 	if err != nil {
 		return err
 	}
+	return x.afterLoad()
+}
+
+// loadMessage loads StrcaseConf's content from msg instead of files, as Load does
+// after reading files. msg is taken over, and stats of reading files are left as is.
+func (x *StrcaseConf) loadMessage(msg proto.Message) error {
+	start := time.Now()
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.data = msg.(*protoconf.StrcaseConf)
+	x.Stats.EntryCount = recordCount(x.data)
+	return x.afterLoad()
+}
+
+// afterLoad backs up and processes StrcaseConf's loaded content.
+func (x *StrcaseConf) afterLoad() error {
 	if x.backup {
 		x.originalData = proto.Clone(x.data).(*protoconf.StrcaseConf)
 	}
-	return x.processAfterLoad()
+	processStart := time.Now()
+	err := x.processAfterLoad()
+	x.Stats.ProcessAfterLoadDuration = time.Since(processStart)
+	if err != nil {
+		return &LoadError{Messager: x.Name(), Phase: PhaseProcessAfterLoad, Err: err}
+	}
+	return nil
+}
+
+// LoadContext loads StrcaseConf's content like Load, but aborts when ctx is done.
+// The files are loaded into a new message, which is taken only if loaded
+// successfully, so that StrcaseConf is left untouched if aborted.
+func (x *StrcaseConf) LoadContext(ctx context.Context, dir string, format format.Format, opts *load.MessagerOptions) error {
+	start := time.Now()
+	data := &protoconf.StrcaseConf{}
+	stats := Stats{}
+	err := loadContext(ctx, x.Name(), opts, func(opts *load.MessagerOptions) error {
+		return loadMessagerInDir(data, dir, format, opts, &stats)
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		x.Stats.Duration = time.Since(start)
+	}()
+	x.Stats = stats
+	x.data = data
+	return x.afterLoad()
+}
+
+// LoadFS loads StrcaseConf's content in the given dir of fsys, based on format and messager options.
+func (x *StrcaseConf) LoadFS(fsys fs.FS, dir string, format format.Format, opts *load.MessagerOptions) error {
+	mopts, err := fsMessagerOptions(fsys, x.Name(), dir, format, opts)
+	if err != nil {
+		return err
+	}
+	return x.Load(dir, format, mopts)
 }
 
 // Store stores StrcaseConf's content to file in the specified directory and format.
@@ -6,11 +6,18 @@
 package loader
 
 import (
+	"cmp"
 	"errors"
+	"maps"
+	"slices"
 )
 
 var ErrNotFound = errors.New("not found")
 
+// ErrNotSupported is returned when a messager does not support the
+// requested way of loading, e.g. loading a custom messager from an fs.FS.
+var ErrNotSupported = errors.New("not supported")
+
 func boolToInt(ok bool) int {
 	if ok {
 		return 1
@@ -18,6 +25,22 @@ func boolToInt(ok bool) int {
 	return 0
 }
 
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	return slices.Sorted(maps.Keys(m))
+}
+
+// sortedBoolKeys returns the keys of m in sorted order, false first.
+func sortedBoolKeys[V any](m map[bool]V) []bool {
+	keys := make([]bool, 0, len(m))
+	for _, key := range []bool{false, true} {
+		if _, ok := m[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
 // GetMessager gets a messager from provided [MessagerMap]. It will return nil
 // if not found by messager name.
 func GetMessager[T Messager](messagerMap MessagerMap) T {
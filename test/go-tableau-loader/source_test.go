@@ -0,0 +1,149 @@
+package loader_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/tableauio/loader/pkg/source"
+	"github.com/tableauio/loader/test/go-tableau-loader/hub"
+	"github.com/tableauio/tableau/format"
+	"github.com/tableauio/tableau/load"
+	"google.golang.org/protobuf/proto"
+)
+
+// confServer serves config files with ETags, as a config server does.
+type confServer struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+func newConfServer(t *testing.T) (*confServer, *httptest.Server) {
+	t.Helper()
+	s := &confServer{files: map[string][]byte{}}
+	for dir, prefix := range map[string]string{"../testdata/conf": "", "../testdata/patchconf": "patchconf/"} {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", dir, err)
+		}
+		for _, entry := range entries {
+			content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+			if err != nil {
+				t.Fatalf("failed to read %s: %v", entry.Name(), err)
+			}
+			s.files[prefix+entry.Name()] = content
+		}
+	}
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+	return s, server
+}
+
+func (s *confServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	name := strings.TrimPrefix(r.URL.Path, "/")
+	if name == "" {
+		names := make([]string, 0, len(s.files))
+		for name := range s.files {
+			names = append(names, name)
+		}
+		_ = json.NewEncoder(w).Encode(names)
+		return
+	}
+	content, ok := s.files[name]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	checksum := sha256.Sum256(content)
+	etag := `"` + hex.EncodeToString(checksum[:]) + `"`
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("ETag", etag)
+	_, _ = w.Write(content)
+}
+
+func (s *confServer) set(name string, content []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.files[name] = content
+}
+
+func Test_LoadSource(t *testing.T) {
+	ctx := context.Background()
+	s, server := newConfServer(t)
+	src := source.NewHTTP(server.URL, nil)
+	h := hub.NewMyHub()
+	options := []load.Option{load.IgnoreUnknownFields(), load.PatchDirs("patchconf")}
+	if err := h.LoadSource(ctx, src, format.JSON, options...); err != nil {
+		t.Fatalf("failed to load from source: %v", err)
+	}
+	expected := hub.NewMyHub()
+	err := expected.Load("../testdata/conf/", format.JSON,
+		load.IgnoreUnknownFields(),
+		load.PatchDirs("../testdata/patchconf/"),
+	)
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	for name, msger := range expected.GetMessagerMap() {
+		if !proto.Equal(h.GetMessager(name).Message(), msger.Message()) {
+			t.Fatalf("%s loaded from source differs", name)
+		}
+	}
+
+	// unchanged messagers are skipped
+	oldItemConf := h.GetItemConf()
+	oldPatchReplaceConf := h.GetPatchReplaceConf()
+	if err := h.LoadSource(ctx, src, format.JSON, options...); err != nil {
+		t.Fatalf("failed to load from source: %v", err)
+	}
+	if h.GetItemConf() != oldItemConf || h.GetPatchReplaceConf() != oldPatchReplaceConf {
+		t.Fatal("unchanged messagers should be reused")
+	}
+
+	// changed messagers are loaded, including those with changed patch files
+	s.set(path.Join("patchconf", "PatchReplaceConf.json"), []byte(`{"name": "changed"}`))
+	if err := h.LoadSource(ctx, src, format.JSON, options...); err != nil {
+		t.Fatalf("failed to load from source: %v", err)
+	}
+	if h.GetItemConf() != oldItemConf {
+		t.Fatal("unchanged ItemConf should be reused")
+	}
+	if h.GetPatchReplaceConf() == oldPatchReplaceConf || h.GetPatchReplaceConf().Data().GetName() != "changed" {
+		t.Fatal("PatchReplaceConf should be loaded with changed patch file")
+	}
+
+	// files of the same name in other dirs are not config files of ItemConf
+	s.set(path.Join("other", "ItemConf.json"), []byte(`{}`))
+	if err := h.LoadSource(ctx, src, format.JSON, options...); err != nil {
+		t.Fatalf("failed to load from source: %v", err)
+	}
+	if h.GetItemConf() != oldItemConf {
+		t.Fatal("ItemConf should be reused when a file of the same name in another dir changed")
+	}
+
+	// changed load options invalidate reuse
+	oldPatchReplaceConf = h.GetPatchReplaceConf()
+	if err := h.LoadSource(ctx, src, format.JSON, load.IgnoreUnknownFields()); err != nil {
+		t.Fatalf("failed to load from source: %v", err)
+	}
+	if h.GetItemConf() == oldItemConf || h.GetPatchReplaceConf() == oldPatchReplaceConf {
+		t.Fatal("messagers should be loaded when load options changed")
+	}
+	if h.GetPatchReplaceConf().Data().GetName() == "changed" {
+		t.Fatal("PatchReplaceConf should be loaded without patch dirs")
+	}
+}
@@ -0,0 +1,71 @@
+package loader_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tableauio/loader/test/go-tableau-loader/hub"
+	"github.com/tableauio/loader/test/go-tableau-loader/protoconf/loader"
+	"github.com/tableauio/tableau/format"
+	"github.com/tableauio/tableau/load"
+)
+
+func init() {
+	loader.RegisterValidator(func(conf *loader.ItemConf, mc *loader.MessagerContainer) error {
+		for key, item := range conf.Data().GetItemMap() {
+			if key != item.GetId() {
+				return fmt.Errorf("item key %d mismatches ID %d", key, item.GetId())
+			}
+		}
+		return nil
+	})
+	loader.RegisterValidator(func(conf *loader.HeroConf, mc *loader.MessagerContainer) error {
+		for key, hero := range conf.Data().GetHeroMap() {
+			if key != hero.GetName() {
+				return fmt.Errorf("hero key %q mismatches name %q", key, hero.GetName())
+			}
+		}
+		return nil
+	})
+}
+
+func Test_Validator(t *testing.T) {
+	dir := copyConfDir(t)
+	files := map[string]string{
+		"ItemConf.json": `{"itemMap": {"1": {"id": 1, "name": "apple"}, "2": {"id": 3}}}`,
+		"HeroConf.json": `{"heroMap": {"zeus": {"name": "venus"}}}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	h := prepareHub(t)
+	old := h.GetItemConf()
+	err := h.Load(dir, format.JSON, load.IgnoreUnknownFields())
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("expected joined error, got: %v", err)
+	}
+	errs := joined.Unwrap()
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 violations, got: %v", errs)
+	}
+	for i, name := range []string{"HeroConf", "ItemConf"} {
+		var loadErr *loader.LoadError
+		if !errors.As(errs[i], &loadErr) || loadErr.Messager != name || loadErr.Phase != loader.PhaseValidate {
+			t.Fatalf("unexpected violation: %v", errs[i])
+		}
+	}
+	if h.GetItemConf() != old {
+		t.Fatal("container should be kept when validation failed")
+	}
+
+	if err := hub.NewMyHub(loader.SkipValidators()).Load(dir, format.JSON, load.IgnoreUnknownFields()); err != nil {
+		t.Fatalf("failed to load with validators skipped: %v", err)
+	}
+}
@@ -0,0 +1,162 @@
+package loader_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tableauio/loader/pkg/fswatch"
+	"github.com/tableauio/loader/test/go-tableau-loader/hub"
+	"github.com/tableauio/loader/test/go-tableau-loader/protoconf/loader"
+	"github.com/tableauio/tableau/format"
+	"github.com/tableauio/tableau/load"
+	"google.golang.org/protobuf/proto"
+)
+
+// copyConfDir copies all files in testdata/conf to a temp dir.
+func copyConfDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	entries, err := os.ReadDir("../testdata/conf")
+	if err != nil {
+		t.Fatalf("failed to read conf dir: %v", err)
+	}
+	for _, entry := range entries {
+		content, err := os.ReadFile(filepath.Join("../testdata/conf", entry.Name()))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", entry.Name(), err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, entry.Name()), content, 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", entry.Name(), err)
+		}
+	}
+	return dir
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timeout waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func Test_Watch(t *testing.T) {
+	dir := copyConfDir(t)
+	errCh := make(chan error, 1)
+	started := make(chan struct{})
+	poll := fswatch.Poll(10 * time.Millisecond)
+	h := hub.NewMyHub(loader.WithHotReload(&loader.HotReload{
+		Backend: func(ctx context.Context, dirs []string) (<-chan struct{}, error) {
+			defer close(started)
+			return poll(ctx, dirs)
+		},
+		Debounce: 50 * time.Millisecond,
+		OnError: func(err error) {
+			select {
+			case errCh <- err:
+			default:
+			}
+		},
+	}))
+	if err := h.Load(dir, format.JSON, load.IgnoreUnknownFields()); err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- h.Watch(ctx, dir, format.JSON, load.IgnoreUnknownFields())
+	}()
+	<-started
+
+	// change config, then it should be reloaded
+	oldConf := h.GetItemConf()
+	content, err := os.ReadFile(filepath.Join(dir, "ItemConf.json"))
+	if err != nil {
+		t.Fatalf("failed to read ItemConf.json: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "ItemConf.json"), append(content, '\n'), 0o644); err != nil {
+		t.Fatalf("failed to write ItemConf.json: %v", err)
+	}
+	waitFor(t, "reload", func() bool { return h.GetItemConf() != oldConf })
+
+	// break config, then the current container should be kept
+	oldConf = h.GetItemConf()
+	if err := os.WriteFile(filepath.Join(dir, "ItemConf.json"), []byte("{"), 0o644); err != nil {
+		t.Fatalf("failed to write ItemConf.json: %v", err)
+	}
+	select {
+	case err := <-errCh:
+		t.Logf("reload failed as expected: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for reload error")
+	}
+	if h.GetItemConf() != oldConf {
+		t.Fatal("container should be kept when reload failed")
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got: %v", err)
+	}
+}
+
+func Test_Watch_Profile(t *testing.T) {
+	dir := copyConfDir(t)
+	started := make(chan struct{})
+	var watchedDirs []string
+	poll := fswatch.Poll(10 * time.Millisecond)
+	h := hub.NewMyHub(
+		loader.WithProfile("prod", &loader.Profile{PatchDirs: []string{"../testdata/patchconf/"}}),
+		loader.WithHotReload(&loader.HotReload{
+			Backend: func(ctx context.Context, dirs []string) (<-chan struct{}, error) {
+				defer close(started)
+				watchedDirs = dirs
+				return poll(ctx, dirs)
+			},
+			Debounce: 50 * time.Millisecond,
+		}),
+	)
+	if err := h.LoadProfile(dir, format.JSON, "prod", load.IgnoreUnknownFields()); err != nil {
+		t.Fatalf("failed to load profile: %v", err)
+	}
+	patched := h.GetRecursivePatchConf().Data()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- h.Watch(ctx, dir, format.JSON, load.IgnoreUnknownFields())
+	}()
+	<-started
+	if len(watchedDirs) != 2 || watchedDirs[1] != "../testdata/patchconf/" {
+		t.Fatalf("patch dirs of profile should be watched, got: %v", watchedDirs)
+	}
+
+	// change config, then it should be reloaded with the profile
+	oldConf := h.GetItemConf()
+	content, err := os.ReadFile(filepath.Join(dir, "ItemConf.json"))
+	if err != nil {
+		t.Fatalf("failed to read ItemConf.json: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "ItemConf.json"), append(content, '\n'), 0o644); err != nil {
+		t.Fatalf("failed to write ItemConf.json: %v", err)
+	}
+	waitFor(t, "reload", func() bool { return h.GetItemConf() != oldConf })
+	if h.GetProfile() != "prod" {
+		t.Fatalf("profile should be kept by watch, got %q", h.GetProfile())
+	}
+	if !proto.Equal(h.GetRecursivePatchConf().Data(), patched) {
+		t.Fatalf("reloaded RecursivePatchConf should be patched by profile, got: %v", h.GetRecursivePatchConf().Data())
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got: %v", err)
+	}
+}